@@ -0,0 +1,270 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultAutoPipelineMaxBatch is the default number of commands written at
+// once by auto-pipelining
+const DefaultAutoPipelineMaxBatch = 128
+
+var errAutoPipelineClosed = errors.New("auto-pipeline closed")
+
+// AutoPipelineConfig enables auto-pipelining, see DialConfig.AutoPipeline.
+//
+// Commands of concurrent callers are then written to a single shared
+// connection in batches and their replies handed back in order, so many
+// goroutines share a socket and a round trip instead of taking a pooled
+// connection each. The Redis API is unchanged.
+//
+// Commands which block or change the state of the connection, such as
+// BLPOP, XREAD BLOCK, SELECT, WATCH or CLIENT, keep using pooled
+// connections. A command whose context is done while queued is not sent;
+// once sent its caller returns on cancellation but the reply is still read.
+type AutoPipelineConfig struct {
+	// Window is how long the first command of a batch waits for others.
+	// When 0 a batch is written as soon as the previous one is, holding
+	// the commands queued in the meantime.
+	Window time.Duration
+	// MaxBatch limits the commands written at once, a full batch is written
+	// without waiting for the window to end. DefaultAutoPipelineMaxBatch
+	// is used when 0.
+	MaxBatch int
+}
+
+// unmultiplexed are the commands which block or change the state of the
+// connection they are sent on
+var unmultiplexed = map[string]bool{
+	"blpop": true, "brpop": true, "brpoplpush": true, "blmove": true, "blmpop": true,
+	"bzpopmin": true, "bzpopmax": true, "bzmpop": true, "wait": true, "waitaof": true,
+	"select": true, "auth": true, "hello": true, "reset": true, "quit": true,
+	"multi": true, "exec": true, "discard": true, "watch": true, "unwatch": true,
+	"subscribe": true, "psubscribe": true, "ssubscribe": true, "unsubscribe": true,
+	"punsubscribe": true, "sunsubscribe": true, "monitor": true, "client": true,
+	"readonly": true, "readwrite": true, "asking": true, "sync": true, "psync": true,
+}
+
+// multiplexable reports whether a command may share the auto-pipeline
+// connection
+func multiplexable(args []interface{}) bool {
+	if len(args) == 0 {
+		return false
+	}
+	name := strings.ToLower(argString(args[0]))
+	if unmultiplexed[name] {
+		return false
+	}
+	if name == "xread" || name == "xreadgroup" {
+		for _, arg := range args[1:] {
+			if strings.EqualFold(argString(arg), "BLOCK") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// muxRequest is a command waiting for its reply on the shared connection
+type muxRequest struct {
+	ctx  context.Context
+	args []interface{}
+	done chan muxReply
+}
+
+type muxReply struct {
+	reply *Reply
+	err   error
+}
+
+// autoPipeline batches the commands of concurrent callers on one connection.
+// A writer goroutine collects and writes batches, each connection has a
+// reader goroutine delivering the replies in order.
+type autoPipeline struct {
+	pool     *connPool
+	timeout  time.Duration
+	window   time.Duration
+	maxBatch int
+	requests chan *muxRequest
+	stop     chan struct{}
+	once     sync.Once
+}
+
+func newAutoPipeline(pool *connPool, timeout time.Duration, cfg *AutoPipelineConfig) *autoPipeline {
+	a := &autoPipeline{
+		pool:     pool,
+		timeout:  timeout,
+		window:   cfg.Window,
+		maxBatch: cfg.MaxBatch,
+		requests: make(chan *muxRequest),
+		stop:     make(chan struct{}),
+	}
+	if a.maxBatch <= 0 {
+		a.maxBatch = DefaultAutoPipelineMaxBatch
+	}
+	go a.run()
+	return a
+}
+
+// execute queues a command and waits for its reply
+func (a *autoPipeline) execute(ctx context.Context, args []interface{}) (*Reply, error) {
+	req := &muxRequest{ctx: ctx, args: args, done: make(chan muxReply, 1)}
+	select {
+	case a.requests <- req:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-a.stop:
+		return nil, errAutoPipelineClosed
+	}
+	select {
+	case rep := <-req.done:
+		return rep.reply, rep.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (a *autoPipeline) close() {
+	a.once.Do(func() { close(a.stop) })
+}
+
+// run writes batches of requests until the auto-pipeline is closed
+func (a *autoPipeline) run() {
+	var mc *muxConn
+	for {
+		var batch []*muxRequest
+		select {
+		case req := <-a.requests:
+			batch = append(batch, req)
+		case <-a.stop:
+			if mc != nil {
+				mc.retire()
+			}
+			return
+		}
+		batch = a.collect(batch)
+		if mc != nil && (mc.failed.Load() || a.pool.stale(mc.conn)) {
+			mc.retire()
+			mc = nil
+		}
+		if mc == nil {
+			c, err := a.pool.Get()
+			if err != nil {
+				for _, req := range batch {
+					req.done <- muxReply{err: err}
+				}
+				continue
+			}
+			mc = &muxConn{conn: c, pending: make(chan *muxRequest, a.maxBatch)}
+			go mc.read(a.pool, a.timeout)
+		}
+		if !a.write(mc, batch) {
+			mc.failed.Store(true)
+			mc.conn.Conn.Close()
+			mc.retire()
+			mc = nil
+		}
+	}
+}
+
+// collect adds queued requests to batch until it is full, waiting for the
+// window if one is set
+func (a *autoPipeline) collect(batch []*muxRequest) []*muxRequest {
+	if a.window <= 0 {
+		for len(batch) < a.maxBatch {
+			select {
+			case req := <-a.requests:
+				batch = append(batch, req)
+			default:
+				return batch
+			}
+		}
+		return batch
+	}
+	t := time.NewTimer(a.window)
+	defer t.Stop()
+	for len(batch) < a.maxBatch {
+		select {
+		case req := <-a.requests:
+			batch = append(batch, req)
+		case <-t.C:
+			return batch
+		}
+	}
+	return batch
+}
+
+// write sends a batch on mc and hands the requests to its reader, returning
+// false if the connection failed
+func (a *autoPipeline) write(mc *muxConn, batch []*muxRequest) bool {
+	var request []byte
+	sent := batch[:0]
+	for _, req := range batch {
+		if err := req.ctx.Err(); err != nil {
+			req.done <- muxReply{err: err}
+			continue
+		}
+		b, err := packCommand(req.args...)
+		if err != nil {
+			req.done <- muxReply{err: err}
+			continue
+		}
+		request = append(request, b...)
+		sent = append(sent, req)
+	}
+	if len(sent) == 0 {
+		return true
+	}
+	mc.conn.Conn.SetWriteDeadline(time.Now().Add(a.timeout))
+	if _, err := mc.conn.Conn.Write(request); err != nil {
+		for _, req := range sent {
+			req.done <- muxReply{err: err}
+		}
+		return false
+	}
+	for _, req := range sent {
+		mc.pending <- req
+	}
+	return true
+}
+
+// muxConn is a connection shared by auto-pipelining
+type muxConn struct {
+	conn    *connection
+	pending chan *muxRequest
+	failed  atomic.Bool
+}
+
+// read delivers the replies to the requests written on the connection, in
+// order. After a failure the remaining requests get the same error. Once
+// retired, the connection goes back to the pool when all replies are read.
+func (mc *muxConn) read(pool *connPool, timeout time.Duration) {
+	var err error
+	for req := range mc.pending {
+		rep := muxReply{err: err}
+		if err == nil {
+			mc.conn.Conn.SetReadDeadline(time.Now().Add(timeout))
+			// error replies such as NOAUTH come with an error
+			if rep.reply, rep.err = mc.conn.RecvReply(); rep.reply == nil {
+				err = rep.err
+				mc.failed.Store(true)
+				mc.conn.Conn.Close()
+			}
+		}
+		req.done <- rep
+	}
+	if mc.failed.Load() {
+		pool.Discard(mc.conn)
+		return
+	}
+	pool.Put(mc.conn)
+}
+
+// retire stops writing on the connection
+func (mc *muxConn) retire() {
+	close(mc.pending)
+}
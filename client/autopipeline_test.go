@@ -0,0 +1,98 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// serveEcho answers every command with its last argument as a bulk reply
+// and counts the connections accepted
+func serveEcho(t *testing.T) (string, *int32) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	var conns int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&conns, 1)
+			go func() {
+				defer conn.Close()
+				c := &connection{Conn: conn, Reader: bufio.NewReader(conn)}
+				for {
+					rp, err := c.RecvReply()
+					if err != nil {
+						return
+					}
+					args, _ := rp.ListValue()
+					last := args[len(args)-1]
+					if _, err := fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(last), last); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String(), &conns
+}
+
+func TestAutoPipeline(t *testing.T) {
+	for _, cfg := range []AutoPipelineConfig{{}, {Window: 5 * time.Millisecond, MaxBatch: 4}} {
+		addr, conns := serveEcho(t)
+		rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second, AutoPipeline: &cfg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key := fmt.Sprintf("key%d", i)
+				value, err := rr.Get(key)
+				if err != nil {
+					t.Error(err)
+				} else if string(value) != key {
+					t.Errorf("%s got the reply %q", key, value)
+				}
+			}()
+		}
+		wg.Wait()
+		if n := atomic.LoadInt32(conns); n != 1 {
+			t.Errorf("%+v: expected a single shared connection, got %d", cfg, n)
+		}
+		rr.ClosePool()
+		if _, err := rr.Get("key"); err == nil {
+			t.Error("expected error after ClosePool")
+		}
+	}
+}
+
+func TestMultiplexable(t *testing.T) {
+	cases := []struct {
+		args []interface{}
+		mux  bool
+	}{
+		{[]interface{}{"GET", "key"}, true},
+		{[]interface{}{"xread", "COUNT", 1, "STREAMS", "s", "0"}, true},
+		{[]interface{}{"XREAD", "BLOCK", 0, "STREAMS", "s", "$"}, false},
+		{[]interface{}{"BLPOP", "list", 0}, false},
+		{[]interface{}{"select", 1}, false},
+		{[]interface{}{"CLIENT", "SETNAME", "x"}, false},
+	}
+	for _, c := range cases {
+		if got := multiplexable(c.args); got != c.mux {
+			t.Errorf("multiplexable(%v) = %v", c.args, got)
+		}
+	}
+}
@@ -0,0 +1,572 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TrackingMode selects how the server tracks the keys of a client side cache
+type TrackingMode int
+
+const (
+	// TrackingDefault tracks the keys read by the cache and sends one
+	// invalidation for each when it is modified.
+	TrackingDefault TrackingMode = iota
+	// TrackingBroadcast sends invalidations for every modified key matching
+	// CacheOptions.Prefixes, whether the cache read it or not.
+	TrackingBroadcast
+	// TrackingOptIn only tracks reads preceded by CLIENT CACHING yes, which
+	// the cache sends before each read.
+	TrackingOptIn
+)
+
+// DefaultCacheMaxEntries is the default number of keys kept by a client cache
+const DefaultCacheMaxEntries = 10000
+
+// CacheOptions configures a client side cache, see Redis.WithClientCache
+type CacheOptions struct {
+	Mode TrackingMode
+	// Prefixes are the key prefixes tracked in TrackingBroadcast mode, keys
+	// outside them are not cached. No prefixes tracks every key.
+	Prefixes []string
+	// MaxEntries bounds the number of cached keys, the least recently used
+	// are evicted first. Defaults to DefaultCacheMaxEntries.
+	MaxEntries int
+	// TTL expires cached keys after this long even without an invalidation,
+	// 0 keeps them until invalidated or evicted.
+	TTL time.Duration
+	// Redirect receives invalidations on a second connection subscribed to
+	// __redis__:invalidate rather than as RESP3 push frames on the
+	// tracking connection. It is always used with RESP2.
+	Redirect bool
+}
+
+// CacheStats reports the activity of a client side cache
+type CacheStats struct {
+	Hits          uint64 // reads served from the cache
+	Misses        uint64 // reads sent to the server
+	Invalidations uint64 // keys invalidated by the server
+	Flushes       uint64 // whole cache flushes, on FLUSHALL or connection loss
+	Entries       int    // keys currently cached
+}
+
+// CachedClient is a Redis client which keeps the replies of Get and HGetAll
+// in a local cache. Reads are sent on a dedicated connection with CLIENT
+// TRACKING enabled, and the server tells the client when a cached key is
+// modified. The cache is flushed when the tracking connection is lost, as
+// invalidations may have been missed, and the connection is redialed on the
+// next read.
+//
+// All other command methods of Redis are available on CachedClient and are
+// not cached.
+type CachedClient struct {
+	*Redis
+
+	opts     CacheOptions
+	redirect bool
+	mutex    sync.Mutex
+	entries  map[string]*list.Element
+	lru      *list.List
+	fetches  map[string][]*cacheFetch
+	tracker  *tracker
+	closed   bool
+
+	hits          uint64
+	misses        uint64
+	invalidations uint64
+	flushes       uint64
+}
+
+// cacheEntry holds the cached replies of one key, by command name
+type cacheEntry struct {
+	key     string
+	replies map[string]*Reply
+	expires time.Time
+}
+
+// cacheFetch is a read in flight, marked stale when its key is invalidated
+// before the reply is cached
+type cacheFetch struct {
+	stale bool
+}
+
+// WithClientCache returns a client caching the replies of Get and HGetAll
+// under server assisted invalidation. Requires Redis 6.0+
+//
+//	cached, err := client.WithClientCache(&CacheOptions{MaxEntries: 1000, TTL: time.Minute})
+//	value, err := cached.Get("key") // read from the server
+//	value, err = cached.Get("key")  // read from the cache until key changes
+//
+// opts may be nil. The tracking connection is dialed like the connections of
+// the pool of r, so the cache does not follow cluster redirections, and moves
+// to the new master of a sentinel failover once the old one is lost.
+func (r *Redis) WithClientCache(opts *CacheOptions) (*CachedClient, error) {
+	c := &CachedClient{
+		Redis:   r,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		fetches: make(map[string][]*cacheFetch),
+	}
+	if opts != nil {
+		c.opts = *opts
+	}
+	if c.opts.MaxEntries <= 0 {
+		c.opts.MaxEntries = DefaultCacheMaxEntries
+	}
+	c.redirect = c.opts.Redirect || r.protocol != 3
+	t, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	c.tracker = t
+	return c, nil
+}
+
+// Get returns the value of key from the cache, or from the server when it
+// is not cached. See Redis.Get.
+func (c *CachedClient) Get(key string) ([]byte, error) {
+	rp, err := c.cached("GET", key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := rp.BytesValue()
+	if err != nil || value == nil {
+		return nil, err
+	}
+	// The cached reply is shared, so callers get their own copy
+	return append([]byte(nil), value...), nil
+}
+
+// HGetAll returns the hash stored at key from the cache, or from the server
+// when it is not cached. See Redis.HGetAll.
+func (c *CachedClient) HGetAll(key string) (map[string]string, error) {
+	rp, err := c.cached("HGETALL", key)
+	if err != nil {
+		return nil, err
+	}
+	return rp.HashValue()
+}
+
+// CacheStats returns the cache counters
+func (c *CachedClient) CacheStats() CacheStats {
+	c.mutex.Lock()
+	entries := c.lru.Len()
+	c.mutex.Unlock()
+	return CacheStats{
+		Hits:          atomic.LoadUint64(&c.hits),
+		Misses:        atomic.LoadUint64(&c.misses),
+		Invalidations: atomic.LoadUint64(&c.invalidations),
+		Flushes:       atomic.LoadUint64(&c.flushes),
+		Entries:       entries,
+	}
+}
+
+// Close drops the cache and closes the tracking connections.
+// The underlying Redis client stays usable.
+func (c *CachedClient) Close() error {
+	c.mutex.Lock()
+	c.closed = true
+	t := c.tracker
+	c.tracker = nil
+	c.flushLocked()
+	c.mutex.Unlock()
+	if t != nil {
+		t.close(errors.New("client cache closed"))
+	}
+	return nil
+}
+
+// cacheable reports whether replies for key may be cached
+func (c *CachedClient) cacheable(key string) bool {
+	if c.opts.Mode != TrackingBroadcast || len(c.opts.Prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range c.opts.Prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// cached returns the reply to a single key read command, from the cache when
+// possible
+func (c *CachedClient) cached(cmd, key string) (*Reply, error) {
+	if !c.cacheable(key) {
+		return c.Redis.ExecuteCommand(cmd, key)
+	}
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return c.Redis.ExecuteCommand(cmd, key)
+	}
+	if rp := c.lookupLocked(cmd, key); rp != nil {
+		c.mutex.Unlock()
+		atomic.AddUint64(&c.hits, 1)
+		return rp, nil
+	}
+	atomic.AddUint64(&c.misses, 1)
+	t := c.tracker
+	if t == nil {
+		// redial without holding up the cache hits and invalidations
+		c.mutex.Unlock()
+		dialed, err := c.connect()
+		if err != nil {
+			return nil, err
+		}
+		c.mutex.Lock()
+		if c.closed {
+			c.mutex.Unlock()
+			dialed.close(errors.New("client cache closed"))
+			return c.Redis.ExecuteCommand(cmd, key)
+		}
+		if c.tracker == nil {
+			c.tracker = dialed
+		} else {
+			// another read redialed meanwhile
+			defer dialed.close(errors.New("tracking connection not needed"))
+		}
+		t = c.tracker
+	}
+	fetch := &cacheFetch{}
+	c.fetches[key] = append(c.fetches[key], fetch)
+	c.mutex.Unlock()
+
+	cmds := [][]interface{}{{cmd, key}}
+	if c.opts.Mode == TrackingOptIn {
+		cmds = [][]interface{}{{"CLIENT", "CACHING", "yes"}, {cmd, key}}
+	}
+	rp, err := t.execute(c.Context(), c.timeout, cmds...)
+
+	c.mutex.Lock()
+	fetches := c.fetches[key]
+	for i, f := range fetches {
+		if f == fetch {
+			fetches = append(fetches[:i], fetches[i+1:]...)
+			break
+		}
+	}
+	if len(fetches) == 0 {
+		delete(c.fetches, key)
+	} else {
+		c.fetches[key] = fetches
+	}
+	if err == nil && rp.Type != ErrorReply && !fetch.stale && !c.closed {
+		c.storeLocked(cmd, key, rp)
+	}
+	c.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if rp.Type == ErrorReply {
+		return rp, newRedisError(rp.Error)
+	}
+	return rp, nil
+}
+
+// lookupLocked returns the cached reply of cmd for key, or nil
+func (c *CachedClient) lookupLocked(cmd, key string) *Reply {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil
+	}
+	rp, ok := entry.replies[cmd]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(elem)
+	return rp
+}
+
+// storeLocked caches the reply of cmd for key, evicting the least recently
+// used key when the cache is full
+func (c *CachedClient) storeLocked(cmd, key string, rp *Reply) {
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*cacheEntry).replies[cmd] = rp
+		c.lru.MoveToFront(elem)
+		return
+	}
+	entry := &cacheEntry{key: key, replies: map[string]*Reply{cmd: rp}}
+	if c.opts.TTL > 0 {
+		entry.expires = time.Now().Add(c.opts.TTL)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.opts.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// invalidate drops the given keys, or the whole cache for a null reply
+func (c *CachedClient) invalidate(keys *Reply) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if keys.IsNull() {
+		c.flushLocked()
+		return
+	}
+	for _, k := range keys.Multi {
+		key, err := k.keyString()
+		if err != nil {
+			continue
+		}
+		atomic.AddUint64(&c.invalidations, 1)
+		if elem, ok := c.entries[key]; ok {
+			c.lru.Remove(elem)
+			delete(c.entries, key)
+		}
+		for _, f := range c.fetches[key] {
+			f.stale = true
+		}
+	}
+}
+
+// flushLocked drops every cached key and marks reads in flight stale
+func (c *CachedClient) flushLocked() {
+	atomic.AddUint64(&c.flushes, 1)
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	for _, fetches := range c.fetches {
+		for _, f := range fetches {
+			f.stale = true
+		}
+	}
+}
+
+// lost flushes the cache after the tracking connection t failed, as
+// invalidations may have been missed
+func (c *CachedClient) lost(t *tracker, err error) {
+	c.mutex.Lock()
+	if c.tracker == t {
+		c.tracker = nil
+		c.flushLocked()
+	}
+	c.mutex.Unlock()
+	t.close(err)
+}
+
+// handlePush handles an invalidation, received either as a RESP3
+// push frame, >2 invalidate keys, or as a message on __redis__:invalidate,
+// message __redis__:invalidate keys
+func (c *CachedClient) handlePush(rp *Reply) {
+	if len(rp.Multi) < 2 {
+		return
+	}
+	kind, _ := rp.Multi[0].keyString()
+	switch {
+	case kind == "invalidate":
+		c.invalidate(rp.Multi[1])
+	case kind == "message" && len(rp.Multi) == 3:
+		c.invalidate(rp.Multi[2])
+	}
+}
+
+// connect dials a tracking connection, and the connection receiving its
+// invalidations in redirect mode. It must be called without holding mutex.
+func (c *CachedClient) connect() (*tracker, error) {
+	conn, err := c.Redis.pool.dial()
+	if err != nil {
+		return nil, err
+	}
+	t := &tracker{conn: conn}
+	args := []interface{}{"CLIENT", "TRACKING", "ON"}
+	if c.redirect {
+		if t.redirect, err = c.Redis.pool.dial(); err != nil {
+			conn.Conn.Close()
+			return nil, err
+		}
+		id, err := t.subscribe(c.timeout)
+		if err != nil {
+			t.close(err)
+			return nil, err
+		}
+		args = append(args, "REDIRECT", id)
+	}
+	switch c.opts.Mode {
+	case TrackingBroadcast:
+		args = append(args, "BCAST")
+		for _, prefix := range c.opts.Prefixes {
+			args = append(args, "PREFIX", prefix)
+		}
+	case TrackingOptIn:
+		args = append(args, "OPTIN")
+	}
+	conn.Conn.SetDeadline(time.Now().Add(c.timeout))
+	rp, err := t.command(conn, args...)
+	if err == nil {
+		err = rp.OKValue()
+	}
+	if err != nil {
+		t.close(err)
+		return nil, err
+	}
+	// Replies and invalidations are read as they arrive from now on
+	conn.Conn.SetDeadline(time.Time{})
+	go c.readReplies(t)
+	if t.redirect != nil {
+		t.redirect.Conn.SetDeadline(time.Time{})
+		go c.readInvalidations(t)
+	}
+	return t, nil
+}
+
+// readReplies hands the replies of the tracking connection to the waiting
+// reads, and handles the push frames in between
+func (c *CachedClient) readReplies(t *tracker) {
+	for {
+		rp, err := t.conn.RecvReply()
+		if rp == nil {
+			c.lost(t, err)
+			return
+		}
+		if rp.Type == PushReply {
+			c.handlePush(rp)
+			continue
+		}
+		t.deliver(trackedReply{rp, err})
+	}
+}
+
+// readInvalidations handles the messages of the redirect connection
+func (c *CachedClient) readInvalidations(t *tracker) {
+	for {
+		rp, err := t.redirect.RecvReply()
+		if rp == nil {
+			c.lost(t, err)
+			return
+		}
+		c.handlePush(rp)
+	}
+}
+
+// tracker is a connection with tracking enabled. Reads are pipelined on it,
+// with one goroutine reading the replies in order.
+type tracker struct {
+	conn     *connection
+	redirect *connection
+	mutex    sync.Mutex
+	pending  []chan trackedReply
+	err      error
+}
+
+type trackedReply struct {
+	rp  *Reply
+	err error
+}
+
+// command sends a command and reads its reply, before the reading
+// goroutines are started
+func (t *tracker) command(conn *connection, args ...interface{}) (*Reply, error) {
+	if err := conn.SendCommand(args...); err != nil {
+		return nil, err
+	}
+	return conn.RecvReply()
+}
+
+// subscribe subscribes the redirect connection to the invalidation channel
+// and returns its client id
+func (t *tracker) subscribe(timeout time.Duration) (int64, error) {
+	t.redirect.Conn.SetDeadline(time.Now().Add(timeout))
+	rp, err := t.command(t.redirect, "CLIENT", "ID")
+	if err != nil {
+		return 0, err
+	}
+	id, err := rp.IntegerValue()
+	if err != nil {
+		return 0, err
+	}
+	if rp, err = t.command(t.redirect, "SUBSCRIBE", "__redis__:invalidate"); err != nil {
+		return 0, err
+	}
+	if rp.Type == ErrorReply {
+		return 0, newRedisError(rp.Error)
+	}
+	return id, nil
+}
+
+// execute pipelines the commands and returns the reply to the last one.
+// A reply not arriving within timeout closes the connection.
+func (t *tracker) execute(ctx context.Context, timeout time.Duration, cmds ...[]interface{}) (*Reply, error) {
+	ch := make(chan trackedReply, len(cmds))
+	t.mutex.Lock()
+	if t.err != nil {
+		t.mutex.Unlock()
+		return nil, t.err
+	}
+	t.conn.Conn.SetWriteDeadline(time.Now().Add(timeout))
+	for _, args := range cmds {
+		if err := t.conn.SendCommand(args...); err != nil {
+			t.mutex.Unlock()
+			t.close(err)
+			return nil, err
+		}
+		t.pending = append(t.pending, ch)
+	}
+	t.mutex.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var reply trackedReply
+	for range cmds {
+		select {
+		case reply = <-ch:
+		case <-ctx.Done():
+			// The reply still arrives in ch, keeping the connection in step
+			return nil, ctx.Err()
+		case <-timer.C:
+			t.close(os.ErrDeadlineExceeded)
+			return nil, os.ErrDeadlineExceeded
+		}
+		// An error to CLIENT CACHING means the read is not tracked either
+		if reply.err != nil || reply.rp.Type == ErrorReply {
+			return reply.rp, reply.err
+		}
+	}
+	return reply.rp, reply.err
+}
+
+// deliver hands a reply to the oldest waiting read
+func (t *tracker) deliver(reply trackedReply) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.pending) == 0 {
+		return
+	}
+	ch := t.pending[0]
+	t.pending = t.pending[1:]
+	ch <- reply
+}
+
+// close closes the connections and fails the waiting reads with err
+func (t *tracker) close(err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.err != nil {
+		return
+	}
+	if err == nil {
+		err = errors.New("tracking connection closed")
+	}
+	t.err = err
+	t.conn.Conn.Close()
+	if t.redirect != nil {
+		t.redirect.Conn.Close()
+	}
+	for _, ch := range t.pending {
+		ch <- trackedReply{nil, err}
+	}
+	t.pending = nil
+}
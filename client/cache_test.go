@@ -0,0 +1,119 @@
+package client
+
+import (
+	"container/list"
+	"testing"
+	"time"
+)
+
+func newTestCache(opts CacheOptions) *CachedClient {
+	return &CachedClient{
+		opts:    opts,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		fetches: make(map[string][]*cacheFetch),
+	}
+}
+
+func TestCacheLRU(t *testing.T) {
+	c := newTestCache(CacheOptions{MaxEntries: 2})
+	for _, key := range []string{"a", "b"} {
+		c.storeLocked("GET", key, &Reply{Type: BulkReply, Bulk: []byte(key)})
+	}
+	// Reading a makes b the least recently used key
+	if rp := c.lookupLocked("GET", "a"); rp == nil {
+		t.Fatal("a should be cached")
+	}
+	c.storeLocked("GET", "c", &Reply{Type: BulkReply, Bulk: []byte("c")})
+	if c.lookupLocked("GET", "b") != nil {
+		t.Error("b should have been evicted")
+	}
+	if c.lookupLocked("GET", "a") == nil || c.lookupLocked("GET", "c") == nil {
+		t.Error("a and c should be cached")
+	}
+	if c.lookupLocked("HGETALL", "a") != nil {
+		t.Error("replies should be cached per command")
+	}
+
+	c = newTestCache(CacheOptions{MaxEntries: 2, TTL: time.Millisecond})
+	c.storeLocked("GET", "a", &Reply{Type: BulkReply, Bulk: []byte("a")})
+	time.Sleep(2 * time.Millisecond)
+	if c.lookupLocked("GET", "a") != nil {
+		t.Error("a should have expired")
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	c := newTestCache(CacheOptions{MaxEntries: 10})
+	c.storeLocked("GET", "foo", &Reply{Type: BulkReply, Bulk: []byte("1")})
+	c.storeLocked("GET", "bar", &Reply{Type: BulkReply, Bulk: []byte("2")})
+	fetch := &cacheFetch{}
+	c.fetches["baz"] = []*cacheFetch{fetch}
+
+	push, err := recvFrom(">2\r\n$10\r\ninvalidate\r\n*2\r\n$3\r\nfoo\r\n$3\r\nbaz\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.handlePush(push)
+	if c.lookupLocked("GET", "foo") != nil {
+		t.Error("foo should have been invalidated")
+	}
+	if c.lookupLocked("GET", "bar") == nil {
+		t.Error("bar should still be cached")
+	}
+	if !fetch.stale {
+		t.Error("read in flight for baz should be stale")
+	}
+
+	// RESP2 redirect message with a null key list flushes everything
+	message, err := recvFrom("*3\r\n$7\r\nmessage\r\n$20\r\n__redis__:invalidate\r\n*-1\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.handlePush(message)
+	stats := c.CacheStats()
+	if stats.Entries != 0 || stats.Flushes != 1 || stats.Invalidations != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCacheBroadcastPrefixes(t *testing.T) {
+	c := newTestCache(CacheOptions{Mode: TrackingBroadcast, Prefixes: []string{"user:"}})
+	if !c.cacheable("user:1") || c.cacheable("order:1") {
+		t.Error("only keys under the broadcast prefixes should be cached")
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	c, err := r.WithClientCache(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	r.Set("key", "value")
+	defer r.Del("key")
+	for i := 0; i < 2; i++ {
+		value, err := c.Get("key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(value) != "value" {
+			t.Errorf("unexpected value %q", value)
+		}
+	}
+	if stats := c.CacheStats(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	r.Set("key", "changed")
+	deadline := time.Now().Add(time.Second)
+	for c.CacheStats().Invalidations == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	value, err := c.Get("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "changed" {
+		t.Errorf("expected invalidated value, got %q", value)
+	}
+}
@@ -0,0 +1,266 @@
+package client
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/therealbill/libredis/structures"
+)
+
+// ClusterInfo returns the CLUSTER INFO fields, such as cluster_state and
+// cluster_slots_assigned, as a map.
+func (r *Redis) ClusterInfo() (map[string]string, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "INFO")
+	if err != nil {
+		return nil, err
+	}
+	text, err := rp.StringValue()
+	if err != nil {
+		return nil, err
+	}
+	info := make(map[string]string)
+	for _, line := range strings.Split(text, "\r\n") {
+		if idx := strings.Index(line, ":"); idx > 0 {
+			info[line[:idx]] = line[idx+1:]
+		}
+	}
+	return info, nil
+}
+
+// ClusterMyID returns the node ID of the connected node.
+func (r *Redis) ClusterMyID() (string, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "MYID")
+	if err != nil {
+		return "", err
+	}
+	return rp.StringValue()
+}
+
+// ClusterKeySlot returns the hash slot the server computes for key.
+// HashSlot computes the same value locally.
+func (r *Redis) ClusterKeySlot(key string) (int64, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "KEYSLOT", key)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ClusterCountKeysInSlot returns the number of keys in the given slot of the
+// connected node.
+func (r *Redis) ClusterCountKeysInSlot(slot int) (int64, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "COUNTKEYSINSLOT", slot)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ClusterGetKeysInSlot returns up to count keys stored in the given slot of
+// the connected node.
+func (r *Redis) ClusterGetKeysInSlot(slot int, count int) ([]string, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "GETKEYSINSLOT", slot, count)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// ClusterSlots returns the slot to node mapping as reported by CLUSTER SLOTS.
+// The result is also stored in r.Slots.
+func (r *Redis) ClusterSlots() ([]structures.ClusterSlot, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "SLOTS")
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]structures.ClusterSlot, 0, len(multi))
+	for _, subrp := range multi {
+		if len(subrp.Multi) < 3 {
+			return nil, errors.New("invalid CLUSTER SLOTS reply")
+		}
+		slot := structures.ClusterSlot{}
+		if slot.Start, err = subrp.Multi[0].IntegerValue(); err != nil {
+			return nil, err
+		}
+		if slot.End, err = subrp.Multi[1].IntegerValue(); err != nil {
+			return nil, err
+		}
+		host, port, err := clusterSlotNode(subrp.Multi[2])
+		if err != nil {
+			return nil, err
+		}
+		slot.MasterHost = host
+		slot.MasterPort = port
+		for _, replica := range subrp.Multi[3:] {
+			host, port, err := clusterSlotNode(replica)
+			if err != nil {
+				return nil, err
+			}
+			slot.Slaves = append(slot.Slaves, host+":"+strconv.FormatInt(port, 10))
+		}
+		slots = append(slots, slot)
+	}
+	r.Slots = slots
+	return slots, nil
+}
+
+// clusterSlotNode reads the host and port of a node entry in CLUSTER SLOTS
+func clusterSlotNode(rp *Reply) (string, int64, error) {
+	if len(rp.Multi) < 2 {
+		return "", 0, errors.New("invalid CLUSTER SLOTS node entry")
+	}
+	host, err := rp.Multi[0].StringValue()
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := rp.Multi[1].IntegerValue()
+	if err != nil {
+		return "", 0, err
+	}
+	return host, port, nil
+}
+
+// ClusterShards returns the shards of the cluster as reported by CLUSTER SHARDS.
+// Redis 7.0+
+func (r *Redis) ClusterShards() ([]structures.ClusterShard, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "SHARDS")
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	shards := make([]structures.ClusterShard, 0, len(multi))
+	for _, subrp := range multi {
+		fields, err := subrp.MapValue()
+		if err != nil {
+			return nil, err
+		}
+		shard := structures.ClusterShard{}
+		if slots, ok := fields["slots"]; ok {
+			for i := 0; i+1 < len(slots.Multi); i += 2 {
+				start, _ := slots.Multi[i].IntegerValue()
+				end, _ := slots.Multi[i+1].IntegerValue()
+				shard.Slots = append(shard.Slots, structures.ClusterSlotRange{Start: start, End: end})
+			}
+		}
+		if nodes, ok := fields["nodes"]; ok {
+			for _, noderp := range nodes.Multi {
+				node, err := parseClusterShardNode(noderp)
+				if err != nil {
+					return nil, err
+				}
+				shard.Nodes = append(shard.Nodes, node)
+			}
+		}
+		shards = append(shards, shard)
+	}
+	return shards, nil
+}
+
+func parseClusterShardNode(rp *Reply) (node structures.ClusterShardNode, err error) {
+	fields, err := rp.MapValue()
+	if err != nil {
+		return node, err
+	}
+	for key, value := range fields {
+		switch key {
+		case "id":
+			node.Id, _ = value.StringValue()
+		case "port":
+			node.Port, _ = value.IntegerValue()
+		case "tls-port":
+			node.TLSPort, _ = value.IntegerValue()
+		case "ip":
+			node.Ip, _ = value.StringValue()
+		case "endpoint":
+			node.Endpoint, _ = value.StringValue()
+		case "hostname":
+			node.Hostname, _ = value.StringValue()
+		case "role":
+			node.Role, _ = value.StringValue()
+		case "replication-offset":
+			node.ReplicationOffset, _ = value.IntegerValue()
+		case "health":
+			node.Health, _ = value.StringValue()
+		}
+	}
+	return node, nil
+}
+
+// ClusterNodes returns the nodes of the cluster as reported by CLUSTER NODES.
+func (r *Redis) ClusterNodes() ([]structures.ClusterNode, error) {
+	rp, err := r.ExecuteCommand("CLUSTER", "NODES")
+	if err != nil {
+		return nil, err
+	}
+	text, err := rp.StringValue()
+	if err != nil {
+		return nil, err
+	}
+	var nodes []structures.ClusterNode
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if line == "" {
+			continue
+		}
+		node, err := parseClusterNode(line)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// parseClusterNode parses one line of CLUSTER NODES output:
+// <id> <ip:port@cport[,hostname]> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ... <slot>
+func parseClusterNode(line string) (node structures.ClusterNode, err error) {
+	fields := strings.Fields(line)
+	if len(fields) < 8 {
+		return node, errors.New("invalid CLUSTER NODES line: " + line)
+	}
+	node.Id = fields[0]
+	node.Address = fields[1]
+	if idx := strings.IndexAny(node.Address, "@,"); idx >= 0 {
+		node.Address = node.Address[:idx]
+	}
+	node.Flags = strings.Split(fields[2], ",")
+	if fields[3] != "-" {
+		node.Master = fields[3]
+	}
+	node.PingSent, _ = strconv.ParseInt(fields[4], 10, 64)
+	node.PongRecv, _ = strconv.ParseInt(fields[5], 10, 64)
+	node.ConfigEpoch, _ = strconv.Atoi(fields[6])
+	node.LinkStateUp = fields[7] == "connected"
+
+	host, port := node.Address, int64(0)
+	if idx := strings.LastIndex(node.Address, ":"); idx >= 0 {
+		host = node.Address[:idx]
+		port, _ = strconv.ParseInt(node.Address[idx+1:], 10, 64)
+	}
+	for _, field := range fields[8:] {
+		// Importing and migrating slots are shown as [slot-<-id] and [slot->-id]
+		if strings.HasPrefix(field, "[") {
+			continue
+		}
+		slot := structures.ClusterSlot{MasterHost: host, MasterPort: port}
+		bounds := strings.SplitN(field, "-", 2)
+		if slot.Start, err = strconv.ParseInt(bounds[0], 10, 64); err != nil {
+			return node, err
+		}
+		slot.End = slot.Start
+		if len(bounds) == 2 {
+			if slot.End, err = strconv.ParseInt(bounds[1], 10, 64); err != nil {
+				return node, err
+			}
+		}
+		node.Slots = append(node.Slots, slot)
+	}
+	return node, nil
+}
@@ -0,0 +1,470 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/therealbill/libredis/structures"
+)
+
+const (
+	// ClusterSlotCount is the number of hash slots in a Redis Cluster
+	ClusterSlotCount = 16384
+
+	// DefaultClusterMaxRedirects is the default number of MOVED, ASK and
+	// TRYAGAIN replies followed for a single command
+	DefaultClusterMaxRedirects = 16
+)
+
+var crc16Table [256]uint16
+
+func init() {
+	// CRC16-CCITT (XMODEM), polynomial 0x1021, as used by Redis Cluster
+	for i := 0; i < 256; i++ {
+		crc := uint16(i) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+		crc16Table[i] = crc
+	}
+}
+
+func crc16(key string) uint16 {
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc = crc<<8 ^ crc16Table[byte(crc>>8)^key[i]]
+	}
+	return crc
+}
+
+// HashSlot returns the cluster hash slot of key.
+// If the key contains a non-empty {hashtag} only the hashtag is hashed, so
+// keys sharing a hashtag are stored in the same slot.
+func HashSlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key) & (ClusterSlotCount - 1))
+}
+
+// ClusterClient is a Redis Cluster client.
+// It keeps a connection pool per master node, routes every command to the
+// node serving the hash slot of its key, and follows MOVED and ASK
+// redirections, reloading the slot map when slots move.
+//
+// All command methods of Redis are available on ClusterClient. Commands
+// without a key are sent to an arbitrary master; multi-key commands are routed
+// by their first key, so their keys must share a hash slot. Pipelining,
+// Transaction and PubSub use a connection to the seed node they were dialed
+// through and are not routed.
+type ClusterClient struct {
+	*Redis
+
+	// MaxRedirects limits the number of redirections followed per command
+	MaxRedirects int
+
+	cfg        DialConfig
+	seeds      []string
+	mutex      sync.RWMutex
+	nodes      map[string]*Redis
+	slots      [ClusterSlotCount]string
+	commands   map[string]structures.CommandEntry
+	refreshing int32
+}
+
+// DialCluster connects to a Redis Cluster through the given seed addresses.
+// cfg is used for every node connection, with its Address replaced by the
+// address of the node; it may be nil.
+func DialCluster(addrs []string, cfg *DialConfig) (*ClusterClient, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("no cluster seed addresses given")
+	}
+	c := &ClusterClient{
+		MaxRedirects: DefaultClusterMaxRedirects,
+		seeds:        addrs,
+		nodes:        make(map[string]*Redis),
+	}
+	if cfg != nil {
+		c.cfg = *cfg
+	}
+	var seed *Redis
+	var err error
+	for _, addr := range addrs {
+		if seed, err = c.node(addr); err == nil {
+			break
+		}
+	}
+	if seed == nil {
+		return nil, err
+	}
+	view := *seed
+	view.cluster = c
+	c.Redis = &view
+	if err := c.ReloadSlots(); err != nil {
+		c.ClosePool()
+		return nil, err
+	}
+	// Key positions are looked up from COMMAND; if unavailable the first
+	// argument after the command name is taken as the key.
+	if entries, err := seed.Command(); err == nil {
+		c.commands = make(map[string]structures.CommandEntry, len(entries))
+		for _, entry := range entries {
+			c.commands[strings.ToLower(entry.Name)] = entry
+		}
+	}
+	return c, nil
+}
+
+// ClosePool closes the connection pools of every known node
+func (c *ClusterClient) ClosePool() {
+	c.mutex.Lock()
+	for addr, node := range c.nodes {
+		node.ClosePool()
+		delete(c.nodes, addr)
+	}
+	c.mutex.Unlock()
+}
+
+// ReloadSlots fetches the slot map from the cluster, using CLUSTER SHARDS
+// and falling back to CLUSTER SLOTS on servers older than Redis 7.0.
+// The result is also stored in Slots.
+func (c *ClusterClient) ReloadSlots() error {
+	c.mutex.RLock()
+	addrs := make([]string, 0, len(c.nodes)+len(c.seeds))
+	for addr := range c.nodes {
+		addrs = append(addrs, addr)
+	}
+	c.mutex.RUnlock()
+	addrs = append(addrs, c.seeds...)
+
+	var lastErr error
+	for _, addr := range addrs {
+		node, err := c.node(addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		slots, err := c.fetchSlots(node)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		c.setSlots(slots)
+		return nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no reachable cluster node")
+	}
+	return lastErr
+}
+
+// fetchSlots asks a single node for the slot map
+func (c *ClusterClient) fetchSlots(node *Redis) ([]structures.ClusterSlot, error) {
+	shards, err := node.ClusterShards()
+	if err != nil {
+		slots, err := node.ClusterSlots()
+		if err != nil {
+			return nil, err
+		}
+		// An empty host means the node answering the query
+		host := node.Address()
+		if idx := strings.LastIndex(host, ":"); idx >= 0 {
+			host = host[:idx]
+		}
+		for i := range slots {
+			if slots[i].MasterHost == "" {
+				slots[i].MasterHost = host
+			}
+		}
+		return slots, nil
+	}
+	var slots []structures.ClusterSlot
+	for _, shard := range shards {
+		var master structures.ClusterSlot
+		var replicas []string
+		for _, n := range shard.Nodes {
+			host := n.Endpoint
+			if host == "" || host == "?" {
+				host = n.Ip
+			}
+			port := n.Port
+			if c.cfg.UseSSL && n.TLSPort > 0 {
+				port = n.TLSPort
+			}
+			if n.Role == "master" {
+				master.MasterHost, master.MasterPort = host, port
+			} else if n.Health == "online" {
+				replicas = append(replicas, host+":"+strconv.FormatInt(port, 10))
+			}
+		}
+		if master.MasterHost == "" {
+			continue
+		}
+		for _, rng := range shard.Slots {
+			slot := master
+			slot.Start, slot.End, slot.Slaves = rng.Start, rng.End, replicas
+			slots = append(slots, slot)
+		}
+	}
+	if len(slots) == 0 {
+		return nil, errors.New("cluster reported no slots")
+	}
+	return slots, nil
+}
+
+// setSlots installs a new slot map and closes pools of nodes no longer
+// serving any slot
+func (c *ClusterClient) setSlots(slots []structures.ClusterSlot) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	masters := make(map[string]bool)
+	for _, slot := range slots {
+		addr := slot.MasterHost + ":" + strconv.FormatInt(slot.MasterPort, 10)
+		masters[addr] = true
+		for i := slot.Start; i <= slot.End && i < ClusterSlotCount; i++ {
+			c.slots[i] = addr
+		}
+	}
+	for addr, node := range c.nodes {
+		if !masters[addr] && node.pool != c.Redis.pool {
+			node.ClosePool()
+			delete(c.nodes, addr)
+		}
+	}
+	c.Redis.Slots = slots
+}
+
+// refreshSlots reloads the slot map in the background, at most one reload
+// runs at a time
+func (c *ClusterClient) refreshSlots() {
+	if !atomic.CompareAndSwapInt32(&c.refreshing, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&c.refreshing, 0)
+		c.ReloadSlots()
+	}()
+}
+
+// node returns the client for addr, dialing it on first use
+func (c *ClusterClient) node(addr string) (*Redis, error) {
+	c.mutex.RLock()
+	node, ok := c.nodes[addr]
+	c.mutex.RUnlock()
+	if ok {
+		return node, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if node, ok := c.nodes[addr]; ok {
+		return node, nil
+	}
+	cfg := c.cfg
+	cfg.Address = addr
+	node, err := DialWithConfig(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.nodes[addr] = node
+	return node, nil
+}
+
+// nodeForSlot returns the client of the master serving slot, or any
+// known master when the slot is unassigned
+func (c *ClusterClient) nodeForSlot(slot int) (*Redis, error) {
+	c.mutex.RLock()
+	addr := ""
+	if slot >= 0 {
+		addr = c.slots[slot]
+	}
+	if addr == "" {
+		for a := range c.nodes {
+			addr = a
+			break
+		}
+	}
+	c.mutex.RUnlock()
+	if addr == "" {
+		return nil, errors.New("no cluster node available")
+	}
+	return c.node(addr)
+}
+
+// ForEachMaster calls fn with the client of every master in the slot map,
+// stopping at the first error
+func (c *ClusterClient) ForEachMaster(fn func(node *Redis) error) error {
+	c.mutex.RLock()
+	seen := make(map[string]bool)
+	var addrs []string
+	for _, addr := range c.slots {
+		if addr != "" && !seen[addr] {
+			seen[addr] = true
+			addrs = append(addrs, addr)
+		}
+	}
+	c.mutex.RUnlock()
+	for _, addr := range addrs {
+		node, err := c.node(addr)
+		if err != nil {
+			return err
+		}
+		if err := fn(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commandKey returns the key a command is routed by, or "" when it has none
+func (c *ClusterClient) commandKey(args []interface{}) string {
+	if len(args) < 2 {
+		return ""
+	}
+	name := strings.ToLower(argString(args[0]))
+	switch name {
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+		if len(args) > 3 && argString(args[2]) != "0" {
+			return argString(args[3])
+		}
+		return ""
+	case "xread", "xreadgroup":
+		for i := 1; i+1 < len(args); i++ {
+			if strings.EqualFold(argString(args[i]), "STREAMS") {
+				return argString(args[i+1])
+			}
+		}
+		return ""
+	case "migrate":
+		if len(args) > 3 && argString(args[3]) != "" {
+			return argString(args[3])
+		}
+		for i := 6; i+1 < len(args); i++ {
+			if strings.EqualFold(argString(args[i]), "KEYS") {
+				return argString(args[i+1])
+			}
+		}
+		return ""
+	}
+	if entry, ok := c.commands[name]; ok {
+		if entry.FirstKey > 0 && int(entry.FirstKey) < len(args) {
+			return argString(args[entry.FirstKey])
+		}
+		return ""
+	}
+	return argString(args[1])
+}
+
+// executeCommand sends a command to the node serving its key, following
+// MOVED, ASK and TRYAGAIN replies up to MaxRedirects times
+func (c *ClusterClient) executeCommand(ctx context.Context, args ...interface{}) (*Reply, error) {
+	slot := -1
+	if key := c.commandKey(args); key != "" {
+		slot = HashSlot(key)
+	}
+	node, err := c.nodeForSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+	asking := false
+	for redirects := 0; ; redirects++ {
+		if ctx != nil {
+			node = node.WithContext(ctx)
+		}
+		var rp *Reply
+		if asking {
+			rp, err = node.executeAsking(args...)
+		} else {
+			rp, err = node.ExecuteCommand(args...)
+		}
+		if rp == nil || rp.Type != ErrorReply || redirects >= c.MaxRedirects {
+			return rp, err
+		}
+		var rerr *RedisError
+		if !errors.As(err, &rerr) {
+			return rp, err
+		}
+		asking = false
+		switch rerr.Prefix {
+		case "MOVED", "ASK":
+			s, addr, ok := rerr.Redirect()
+			if !ok {
+				return rp, err
+			}
+			if rerr.Prefix == "MOVED" {
+				if s >= 0 && s < ClusterSlotCount {
+					c.mutex.Lock()
+					c.slots[s] = addr
+					c.mutex.Unlock()
+				}
+				c.refreshSlots()
+			} else {
+				asking = true
+			}
+			if node, err = c.node(addr); err != nil {
+				return nil, err
+			}
+		case "TRYAGAIN", "CLUSTERDOWN":
+			if rerr.Prefix == "CLUSTERDOWN" {
+				c.refreshSlots()
+			}
+			if err := sleepContext(ctx, time.Duration(redirects+1)*10*time.Millisecond); err != nil {
+				return nil, err
+			}
+		default:
+			return rp, err
+		}
+	}
+}
+
+// executeAsking sends ASKING followed by the command on the same connection,
+// as required after an ASK redirection
+func (r *Redis) executeAsking(args ...interface{}) (*Reply, error) {
+	rp, err := r.roundTrip(r.Context(), []interface{}{"ASKING"}, args)
+	if err != nil {
+		return rp, err
+	}
+	if rp.Type == ErrorReply {
+		return rp, newRedisError(rp.Error)
+	}
+	return rp, nil
+}
+
+// argString returns a command argument as a string
+func argString(arg interface{}) string {
+	switch v := arg.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprint(arg)
+}
+
+// sleepContext sleeps for d, returning early with the context error if ctx,
+// which may be nil, is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
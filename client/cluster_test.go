@@ -0,0 +1,84 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestHashSlot(t *testing.T) {
+	cases := map[string]int{
+		"foo":                12182,
+		"bar":                5061,
+		"123456789":          12739,
+		"{user1000}.follows": HashSlot("user1000"),
+		"foo{}{bar}":         HashSlot("foo{}{bar}"),
+		"foo{{bar}}zap":      HashSlot("{bar"),
+		"foo{bar}{zap}":      HashSlot("bar"),
+	}
+	for key, slot := range cases {
+		if got := HashSlot(key); got != slot {
+			t.Errorf("HashSlot(%q) = %d, should be %d", key, got, slot)
+		}
+	}
+	if HashSlot("{user1000}.following") != HashSlot("{user1000}.followers") {
+		t.Error("keys sharing a hashtag should map to the same slot")
+	}
+}
+
+func TestParseClusterNode(t *testing.T) {
+	line := "07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004,host-a slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected"
+	node, err := parseClusterNode(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Address != "127.0.0.1:30004" || node.Master != "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca" || !node.LinkStateUp {
+		t.Errorf("unexpected node %+v", node)
+	}
+
+	line = "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001@31001 myself,master - 0 0 1 connected 0-5460 5462 [5461->-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1]"
+	node, err = parseClusterNode(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(node.Slots) != 2 {
+		t.Fatalf("expected 2 slot ranges, got %+v", node.Slots)
+	}
+	if node.Slots[0].Start != 0 || node.Slots[0].End != 5460 || node.Slots[1].Start != 5462 || node.Slots[1].End != 5462 {
+		t.Errorf("unexpected slots %+v", node.Slots)
+	}
+	if node.Slots[0].MasterHost != "127.0.0.1" || node.Slots[0].MasterPort != 30001 {
+		t.Errorf("unexpected slot master %+v", node.Slots[0])
+	}
+}
+
+func TestClusterCommandKey(t *testing.T) {
+	c := &ClusterClient{}
+	if key := c.commandKey([]interface{}{"GET", "foo"}); key != "foo" {
+		t.Errorf("GET key = %q", key)
+	}
+	if key := c.commandKey([]interface{}{"EVALSHA", "abc", 1, "foo", "arg"}); key != "foo" {
+		t.Errorf("EVALSHA key = %q", key)
+	}
+	if key := c.commandKey([]interface{}{"EVAL", "return 1", 0}); key != "" {
+		t.Errorf("EVAL without keys should have no key, got %q", key)
+	}
+	if key := c.commandKey([]interface{}{"XREADGROUP", "GROUP", "g", "c", "STREAMS", "s1", ">"}); key != "s1" {
+		t.Errorf("XREADGROUP key = %q", key)
+	}
+	if key := c.commandKey([]interface{}{"MIGRATE", "host", 6379, "foo", 0, int64(1000)}); key != "foo" {
+		t.Errorf("MIGRATE key = %q", key)
+	}
+	if key := c.commandKey([]interface{}{"MIGRATE", "host", 6379, "", 0, int64(1000), "COPY", "KEYS", "a", "b"}); key != "a" {
+		t.Errorf("MIGRATE KEYS key = %q", key)
+	}
+}
+
+func TestClusterInfo(t *testing.T) {
+	info, err := r.ClusterInfo()
+	if err != nil {
+		t.Logf("ClusterInfo failed (server may not be running in cluster mode): %v", err)
+		return
+	}
+	if _, ok := info["cluster_state"]; !ok {
+		t.Errorf("cluster_state missing from %v", info)
+	}
+}
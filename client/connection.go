@@ -46,7 +46,7 @@ func (r *Redis) Hello(protocolVersion int) (map[string]interface{}, error) {
 	}
 	
 	result := make(map[string]interface{})
-	if rp.Type == MultiReply {
+	if rp.Type == MultiReply || rp.Type == MapReply {
 		for i := 0; i < len(rp.Multi); i += 2 {
 			if i+1 < len(rp.Multi) {
 				key, _ := rp.Multi[i].StringValue()
@@ -82,7 +82,7 @@ func (r *Redis) HelloWithOptions(opts HelloOptions) (map[string]interface{}, err
 	}
 	
 	result := make(map[string]interface{})
-	if rp.Type == MultiReply {
+	if rp.Type == MultiReply || rp.Type == MapReply {
 		for i := 0; i < len(rp.Multi); i += 2 {
 			if i+1 < len(rp.Multi) {
 				key, _ := rp.Multi[i].StringValue()
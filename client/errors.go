@@ -0,0 +1,99 @@
+package client
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrNil is returned when a command replies with a null where a value is
+// required, for example IntegerValue on a null bulk reply or GetInt on a
+// missing key. Accessors which can represent a missing value, such as
+// BytesValue returning nil, do not return it.
+var ErrNil = errors.New("redis: nil reply")
+
+// ErrNoExpiry is returned by TTL, PTTL, ExpireTime and PExpireTime for a key
+// which exists but has no expiry. They return ErrNil for a missing key.
+var ErrNoExpiry = errors.New("redis: key has no expiry")
+
+// RedisError is an error reply sent by the server.
+// Prefix is the first word of the reply, such as ERR, WRONGTYPE or MOVED,
+// and Message is the rest of it.
+//
+// The Err variables below hold a RedisError for each well known prefix, so
+// callers can check for them with errors.Is:
+//
+//	if errors.Is(err, client.ErrWrongType) { ... }
+//
+// or get at the full error with errors.As:
+//
+//	var rerr *client.RedisError
+//	if errors.As(err, &rerr) { log.Println(rerr.Prefix, rerr.Message) }
+type RedisError struct {
+	Prefix  string
+	Message string
+}
+
+// Error prefixes sent by the server
+var (
+	ErrGeneric     = &RedisError{Prefix: "ERR"}
+	ErrWrongType   = &RedisError{Prefix: "WRONGTYPE"}
+	ErrMoved       = &RedisError{Prefix: "MOVED"}
+	ErrAsk         = &RedisError{Prefix: "ASK"}
+	ErrTryAgain    = &RedisError{Prefix: "TRYAGAIN"}
+	ErrCrossSlot   = &RedisError{Prefix: "CROSSSLOT"}
+	ErrClusterDown = &RedisError{Prefix: "CLUSTERDOWN"}
+	ErrLoading     = &RedisError{Prefix: "LOADING"}
+	ErrReadOnly    = &RedisError{Prefix: "READONLY"}
+	ErrMasterDown  = &RedisError{Prefix: "MASTERDOWN"}
+	ErrNoScript    = &RedisError{Prefix: "NOSCRIPT"}
+	ErrBusy        = &RedisError{Prefix: "BUSY"}
+	ErrBusyKey     = &RedisError{Prefix: "BUSYKEY"}
+	ErrBusyGroup   = &RedisError{Prefix: "BUSYGROUP"}
+	ErrNoGroup     = &RedisError{Prefix: "NOGROUP"}
+	ErrNoAuth      = &RedisError{Prefix: "NOAUTH"}
+	ErrWrongPass   = &RedisError{Prefix: "WRONGPASS"}
+	ErrNoPerm      = &RedisError{Prefix: "NOPERM"}
+	ErrOOM         = &RedisError{Prefix: "OOM"}
+	ErrExecAbort   = &RedisError{Prefix: "EXECABORT"}
+	ErrNoReplicas  = &RedisError{Prefix: "NOREPLICAS"}
+	ErrUnblocked   = &RedisError{Prefix: "UNBLOCKED"}
+)
+
+// newRedisError splits an error reply into its prefix and message
+func newRedisError(reply string) *RedisError {
+	if idx := strings.IndexByte(reply, ' '); idx >= 0 {
+		return &RedisError{Prefix: reply[:idx], Message: reply[idx+1:]}
+	}
+	return &RedisError{Prefix: reply}
+}
+
+func (e *RedisError) Error() string {
+	if e.Message == "" {
+		return e.Prefix
+	}
+	return e.Prefix + " " + e.Message
+}
+
+// Is reports whether target is one of the prefix errors, such as
+// ErrWrongType, matching the prefix of e.
+func (e *RedisError) Is(target error) bool {
+	t, ok := target.(*RedisError)
+	return ok && t.Message == "" && t.Prefix == e.Prefix
+}
+
+// Redirect returns the slot and node address of a MOVED or ASK error.
+func (e *RedisError) Redirect() (slot int, addr string, ok bool) {
+	if e.Prefix != "MOVED" && e.Prefix != "ASK" {
+		return 0, "", false
+	}
+	fields := strings.Fields(e.Message)
+	if len(fields) != 2 {
+		return 0, "", false
+	}
+	slot, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, "", false
+	}
+	return slot, fields[1], true
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRedisErrorPrefix(t *testing.T) {
+	err := error(newRedisError("WRONGTYPE Operation against a key holding the wrong kind of value"))
+	if !errors.Is(err, ErrWrongType) {
+		t.Errorf("%v should match ErrWrongType", err)
+	}
+	if errors.Is(err, ErrMoved) {
+		t.Errorf("%v should not match ErrMoved", err)
+	}
+	var rerr *RedisError
+	if !errors.As(err, &rerr) {
+		t.Fatal("errors.As failed")
+	}
+	if rerr.Prefix != "WRONGTYPE" || rerr.Message != "Operation against a key holding the wrong kind of value" {
+		t.Errorf("unexpected error %+v", rerr)
+	}
+	if err.Error() != "WRONGTYPE Operation against a key holding the wrong kind of value" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if newRedisError("ERR").Error() != "ERR" {
+		t.Error("error without message should keep its prefix")
+	}
+}
+
+func TestRedisErrorRedirect(t *testing.T) {
+	slot, addr, ok := newRedisError("MOVED 3999 127.0.0.1:6381").Redirect()
+	if !ok || slot != 3999 || addr != "127.0.0.1:6381" {
+		t.Errorf("unexpected redirect %d %s %v", slot, addr, ok)
+	}
+	if _, _, ok := newRedisError("ASK 3999").Redirect(); ok {
+		t.Error("incomplete ASK should not redirect")
+	}
+	if _, _, ok := newRedisError("ERR 3999 127.0.0.1:6381").Redirect(); ok {
+		t.Error("ERR should not redirect")
+	}
+}
+
+func TestReplyErrors(t *testing.T) {
+	rp, _ := recvFrom("-NOSCRIPT No matching script. Please use EVAL.\r\n")
+	if _, err := rp.StringValue(); !errors.Is(err, ErrNoScript) {
+		t.Errorf("expected ErrNoScript, got %v", err)
+	}
+	rp, err := recvFrom("-NOAUTH Authentication required.\r\n")
+	if !errors.Is(err, ErrNoAuth) {
+		t.Errorf("expected ErrNoAuth, got %v", err)
+	}
+	for _, raw := range []string{"$-1\r\n", "*-1\r\n", "_\r\n"} {
+		rp, _ = recvFrom(raw)
+		if _, err := rp.IntegerValue(); err != ErrNil {
+			t.Errorf("%q: expected ErrNil, got %v", raw, err)
+		}
+	}
+	// Accessors which can represent a missing value keep returning it
+	rp, _ = recvFrom("$-1\r\n")
+	if b, err := rp.BytesValue(); b != nil || err != nil {
+		t.Errorf("unexpected BytesValue %v %v", b, err)
+	}
+}
+
+func TestWrongTypeError(t *testing.T) {
+	r.Del("key")
+	r.LPush("key", "value")
+	defer r.Del("key")
+	if _, err := r.Get("key"); !errors.Is(err, ErrWrongType) {
+		t.Errorf("expected ErrWrongType, got %v", err)
+	}
+}
+
+func TestGetIntNil(t *testing.T) {
+	r.Del("key")
+	if _, err := r.GetInt("key"); err != ErrNil {
+		t.Errorf("expected ErrNil, got %v", err)
+	}
+	r.Set("key", "42")
+	defer r.Del("key")
+	if n, err := r.GetInt("key"); err != nil || n != 42 {
+		t.Errorf("expected 42, got %d %v", n, err)
+	}
+}
@@ -0,0 +1,289 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// FUNCTION RESTORE policies
+const (
+	// FunctionRestoreAppend adds the libraries, failing if one exists. This
+	// is the server default.
+	FunctionRestoreAppend = "APPEND"
+	// FunctionRestoreReplace adds the libraries, replacing existing ones
+	FunctionRestoreReplace = "REPLACE"
+	// FunctionRestoreFlush deletes all libraries before restoring
+	FunctionRestoreFlush = "FLUSH"
+)
+
+// FunctionLibrary is a library of functions as returned by FUNCTION LIST
+type FunctionLibrary struct {
+	Name      string
+	Engine    string
+	Functions []FunctionInfo
+	Code      string // only set when listed with code
+}
+
+// FunctionInfo describes a function of a library
+type FunctionInfo struct {
+	Name        string
+	Description string
+	Flags       []string // such as no-writes, allow-oom, allow-stale
+}
+
+// FunctionStats is the reply of FUNCTION STATS
+type FunctionStats struct {
+	Running *RunningFunction // nil when no function is running
+	Engines map[string]FunctionEngineStats
+}
+
+// RunningFunction is the function currently running on the server
+type RunningFunction struct {
+	Name     string
+	Command  []string
+	Duration time.Duration
+}
+
+// FunctionEngineStats counts the libraries and functions of an engine
+type FunctionEngineStats struct {
+	Libraries int64
+	Functions int64
+}
+
+// FunctionLoad loads a library of functions, whose code starts with a
+// shebang naming the engine and library, such as #!lua name=mylib.
+// With replace an existing library of the same name is replaced.
+// Returns the name of the library. Redis 7.0+
+func (r *Redis) FunctionLoad(code string, replace bool) (string, error) {
+	args := []interface{}{"FUNCTION", "LOAD"}
+	if replace {
+		args = append(args, "REPLACE")
+	}
+	rp, err := r.ExecuteCommand(append(args, code)...)
+	if err != nil {
+		return "", err
+	}
+	return rp.StringValue()
+}
+
+// FunctionList returns the libraries whose name matches pattern, all of
+// them when pattern is empty. withCode includes the library code.
+// Redis 7.0+
+func (r *Redis) FunctionList(pattern string, withCode bool) ([]FunctionLibrary, error) {
+	args := []interface{}{"FUNCTION", "LIST"}
+	if pattern != "" {
+		args = append(args, "LIBRARYNAME", pattern)
+	}
+	if withCode {
+		args = append(args, "WITHCODE")
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	libraries := make([]FunctionLibrary, 0, len(multi))
+	for _, subrp := range multi {
+		lib, err := parseFunctionLibrary(subrp)
+		if err != nil {
+			return nil, err
+		}
+		libraries = append(libraries, lib)
+	}
+	return libraries, nil
+}
+
+// parseFunctionLibrary reads a library entry of FUNCTION LIST, a map in
+// RESP3 and alternating field names and values in RESP2
+func parseFunctionLibrary(rp *Reply) (FunctionLibrary, error) {
+	var lib FunctionLibrary
+	err := eachField(rp, func(field string, value *Reply) (err error) {
+		switch field {
+		case "library_name":
+			lib.Name, err = value.StringValue()
+		case "engine":
+			lib.Engine, err = value.StringValue()
+		case "library_code":
+			lib.Code, err = value.StringValue()
+		case "functions":
+			lib.Functions = make([]FunctionInfo, 0, len(value.Multi))
+			for _, fn := range value.Multi {
+				var info FunctionInfo
+				err = eachField(fn, func(field string, value *Reply) (err error) {
+					switch field {
+					case "name":
+						info.Name, err = value.StringValue()
+					case "description":
+						info.Description, err = value.StringValue()
+					case "flags":
+						// simple strings in RESP2, a set in RESP3
+						info.Flags = make([]string, 0, len(value.Multi))
+						for _, flag := range value.Multi {
+							var s string
+							if s, err = flag.keyString(); err != nil {
+								return err
+							}
+							info.Flags = append(info.Flags, s)
+						}
+					}
+					return err
+				})
+				if err != nil {
+					return err
+				}
+				lib.Functions = append(lib.Functions, info)
+			}
+		}
+		return err
+	})
+	return lib, err
+}
+
+// eachField calls fn for each field of a map or RESP2 field value list
+func eachField(rp *Reply, fn func(field string, value *Reply) error) error {
+	if !rp.isAggregate() || len(rp.Multi)%2 != 0 {
+		return errors.New("invalid reply, not a list of field value pairs")
+	}
+	for i := 0; i < len(rp.Multi); i += 2 {
+		field, err := rp.Multi[i].keyString()
+		if err != nil {
+			return err
+		}
+		if err := fn(field, rp.Multi[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FunctionDelete deletes a library and its functions. Redis 7.0+
+func (r *Redis) FunctionDelete(library string) error {
+	rp, err := r.ExecuteCommand("FUNCTION", "DELETE", library)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// FunctionFlush deletes all libraries. Redis 7.0+
+func (r *Redis) FunctionFlush() error {
+	rp, err := r.ExecuteCommand("FUNCTION", "FLUSH")
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// FunctionFlushAsync deletes all libraries in the background. Redis 7.0+
+func (r *Redis) FunctionFlushAsync() error {
+	rp, err := r.ExecuteCommand("FUNCTION", "FLUSH", "ASYNC")
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// FunctionKill kills the running function, which must not have written
+// anything yet. Redis 7.0+
+func (r *Redis) FunctionKill() error {
+	rp, err := r.ExecuteCommand("FUNCTION", "KILL")
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// FunctionStats returns the running function and the number of libraries
+// and functions per engine. Redis 7.0+
+func (r *Redis) FunctionStats() (FunctionStats, error) {
+	stats := FunctionStats{Engines: make(map[string]FunctionEngineStats)}
+	rp, err := r.ExecuteCommand("FUNCTION", "STATS")
+	if err != nil {
+		return stats, err
+	}
+	err = eachField(rp, func(field string, value *Reply) error {
+		switch field {
+		case "running_script":
+			if value.IsNull() {
+				return nil
+			}
+			running := &RunningFunction{}
+			err := eachField(value, func(field string, value *Reply) (err error) {
+				switch field {
+				case "name":
+					running.Name, err = value.StringValue()
+				case "command":
+					running.Command, err = value.ListValue()
+				case "duration_ms":
+					var ms int64
+					ms, err = value.IntegerValue()
+					running.Duration = time.Duration(ms) * time.Millisecond
+				}
+				return err
+			})
+			stats.Running = running
+			return err
+		case "engines":
+			return eachField(value, func(engine string, value *Reply) error {
+				var es FunctionEngineStats
+				err := eachField(value, func(field string, value *Reply) (err error) {
+					switch field {
+					case "libraries_count":
+						es.Libraries, err = value.IntegerValue()
+					case "functions_count":
+						es.Functions, err = value.IntegerValue()
+					}
+					return err
+				})
+				stats.Engines[engine] = es
+				return err
+			})
+		}
+		return nil
+	})
+	return stats, err
+}
+
+// FunctionDump returns a serialized payload of all libraries, to be
+// restored with FunctionRestore. Redis 7.0+
+func (r *Redis) FunctionDump() ([]byte, error) {
+	rp, err := r.ExecuteCommand("FUNCTION", "DUMP")
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesValue()
+}
+
+// FunctionRestore restores libraries from a FunctionDump payload.
+// policy is one of FunctionRestoreAppend, FunctionRestoreReplace or
+// FunctionRestoreFlush, or empty for the server default. Redis 7.0+
+func (r *Redis) FunctionRestore(payload []byte, policy string) error {
+	args := []interface{}{"FUNCTION", "RESTORE", payload}
+	if policy != "" {
+		args = append(args, strings.ToUpper(policy))
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
+// FCall calls a function with keys and arguments, arguments may be strings,
+// []byte or numbers. Use Reply.Scan to read the result into a Go value.
+// Redis 7.0+
+func (r *Redis) FCall(function string, keys []string, args ...interface{}) (*Reply, error) {
+	cmds := append(packArgs("FCALL", function, len(keys), keys), args...)
+	return r.ExecuteCommand(cmds...)
+}
+
+// FCallRO calls a function flagged no-writes, it may run on replicas.
+// Redis 7.0+
+func (r *Redis) FCallRO(function string, keys []string, args ...interface{}) (*Reply, error) {
+	cmds := append(packArgs("FCALL_RO", function, len(keys), keys), args...)
+	return r.ExecuteCommand(cmds...)
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFunctionListParse(t *testing.T) {
+	replies := []string{
+		// RESP2
+		"*1\r\n*8\r\n$12\r\nlibrary_name\r\n$5\r\nmylib\r\n$6\r\nengine\r\n$3\r\nLUA\r\n" +
+			"$9\r\nfunctions\r\n*1\r\n*6\r\n$4\r\nname\r\n$6\r\nmyfunc\r\n$11\r\ndescription\r\n$-1\r\n$5\r\nflags\r\n*1\r\n+no-writes\r\n" +
+			"$12\r\nlibrary_code\r\n$4\r\ncode\r\n",
+		// RESP3
+		"*1\r\n%4\r\n+library_name\r\n$5\r\nmylib\r\n+engine\r\n$3\r\nLUA\r\n" +
+			"+functions\r\n*1\r\n%3\r\n+name\r\n$6\r\nmyfunc\r\n+description\r\n_\r\n+flags\r\n~1\r\n+no-writes\r\n" +
+			"+library_code\r\n$4\r\ncode\r\n",
+	}
+	for _, reply := range replies {
+		addr, commands := serveReplies(t, reply)
+		rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		libs, err := rr.FunctionList("my*", true)
+		rr.ClosePool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd := strings.Join(<-commands, " "); cmd != "FUNCTION LIST LIBRARYNAME my* WITHCODE" {
+			t.Errorf("unexpected command %q", cmd)
+		}
+		if len(libs) != 1 || libs[0].Name != "mylib" || libs[0].Engine != "LUA" || libs[0].Code != "code" {
+			t.Fatalf("unexpected libraries %+v", libs)
+		}
+		fns := libs[0].Functions
+		if len(fns) != 1 || fns[0].Name != "myfunc" || len(fns[0].Flags) != 1 || fns[0].Flags[0] != "no-writes" {
+			t.Errorf("unexpected functions %+v", fns)
+		}
+	}
+}
+
+func TestFunctionStatsParse(t *testing.T) {
+	addr, _ := serveReplies(t, "*4\r\n$14\r\nrunning_script\r\n"+
+		"*6\r\n$4\r\nname\r\n$4\r\nslow\r\n$7\r\ncommand\r\n*2\r\n$5\r\nfcall\r\n$4\r\nslow\r\n$11\r\nduration_ms\r\n:1500\r\n"+
+		"$7\r\nengines\r\n*2\r\n$3\r\nLUA\r\n*4\r\n$15\r\nlibraries_count\r\n:2\r\n$15\r\nfunctions_count\r\n:5\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	stats, err := rr.FunctionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Running == nil || stats.Running.Name != "slow" || stats.Running.Duration != 1500*time.Millisecond {
+		t.Errorf("unexpected running function %+v", stats.Running)
+	}
+	if lua := stats.Engines["LUA"]; lua.Libraries != 2 || lua.Functions != 5 {
+		t.Errorf("unexpected engine stats %+v", stats.Engines)
+	}
+}
@@ -149,16 +149,11 @@ func (r *Redis) GeoDistWithUnit(key, member1, member2, unit string) (float64, er
 		return 0, err
 	}
 
-	if rp.Type == BulkReply && rp.Bulk == nil {
+	if rp.IsNull() {
 		return 0, nil // One or both members don't exist
 	}
 
-	distStr, err := rp.StringValue()
-	if err != nil {
-		return 0, err
-	}
-
-	return strconv.ParseFloat(distStr, 64)
+	return rp.DoubleValue()
 }
 
 // GEOHASH key member [member ...]
@@ -3,6 +3,7 @@ package client
 import (
 	"math"
 	"testing"
+	"time"
 )
 
 // Test geospatial constants
@@ -600,3 +601,18 @@ func TestGeoLargeDataset(t *testing.T) {
 		t.Errorf("Expected at most 50 locations with COUNT 50, got %d", len(locations))
 	}
 }
+
+func TestGeoDistRESP3(t *testing.T) {
+	addr, _ := serveReplies(t, ",166274.1516\r\n", "$11\r\n166274.1516\r\n", "_\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	// RESP3 double, RESP2 bulk string, then a missing member
+	for _, expected := range []float64{166274.1516, 166274.1516, 0} {
+		if d, err := rr.GeoDist("Sicily", "Palermo", "Catania"); err != nil || d != expected {
+			t.Errorf("expected %v, got %v %v", expected, d, err)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package client
 
 import (
+	"errors"
 	"strconv"
+	"time"
 )
 
 // HDel command:
@@ -77,11 +79,7 @@ func (r *Redis) HIncrByFloat(key, field string, increment float64) (float64, err
 	if err != nil {
 		return 0.0, err
 	}
-	s, err := rp.StringValue()
-	if err != nil {
-		return 0.0, err
-	}
-	return strconv.ParseFloat(s, 64)
+	return rp.DoubleValue()
 }
 
 // HKeys command:
@@ -280,3 +278,365 @@ func (r *Redis) HRandFieldWithOptions(key string, opts HRandFieldOptions) ([]HFi
 	
 	return nil, nil
 }
+
+// HExpireResult is the outcome of HEXPIRE and its variants for a field
+type HExpireResult int64
+
+// HEXPIRE results
+const (
+	HExpireNoField      HExpireResult = -2 // the field or the key does not exist
+	HExpireNotSet       HExpireResult = 0  // the NX, XX, GT or LT condition was not met
+	HExpireSet          HExpireResult = 1  // the expiry was set
+	HExpireFieldDeleted HExpireResult = 2  // the expiry was in the past, the field was deleted
+)
+
+func (res HExpireResult) String() string {
+	switch res {
+	case HExpireNoField:
+		return "no field"
+	case HExpireNotSet:
+		return "not set"
+	case HExpireSet:
+		return "set"
+	case HExpireFieldDeleted:
+		return "field deleted"
+	}
+	return "HExpireResult(" + strconv.FormatInt(int64(res), 10) + ")"
+}
+
+// HPersistResult is the outcome of HPERSIST for a field
+type HPersistResult int64
+
+// HPERSIST results
+const (
+	HPersistNoField   HPersistResult = -2 // the field or the key does not exist
+	HPersistNoExpiry  HPersistResult = -1 // the field has no expiry
+	HPersistPersisted HPersistResult = 1  // the expiry was removed
+)
+
+func (res HPersistResult) String() string {
+	switch res {
+	case HPersistNoField:
+		return "no field"
+	case HPersistNoExpiry:
+		return "no expiry"
+	case HPersistPersisted:
+		return "persisted"
+	}
+	return "HPersistResult(" + strconv.FormatInt(int64(res), 10) + ")"
+}
+
+// HFieldState tells whether a hash field exists and expires
+type HFieldState int
+
+// Hash field states
+const (
+	HFieldNoField    HFieldState = iota // the field or the key does not exist
+	HFieldPersistent                    // the field has no expiry
+	HFieldExpiring                      // the field has an expiry
+)
+
+// HFieldTTL is the time to live of a hash field, set when State is
+// HFieldExpiring
+type HFieldTTL struct {
+	State HFieldState
+	TTL   time.Duration
+}
+
+// HFieldExpireTime is the expiry time of a hash field, set when State is
+// HFieldExpiring
+type HFieldExpireTime struct {
+	State HFieldState
+	Time  time.Time
+}
+
+// hFieldsArgs packs a hash field command, the FIELDS argument comes last
+func hFieldsArgs(args []interface{}, fields []string) []interface{} {
+	args = append(args, "FIELDS", len(fields))
+	for _, f := range fields {
+		args = append(args, f)
+	}
+	return args
+}
+
+// hIntegersValue reads the per field integer replies of the HEXPIRE family
+func hIntegersValue(rp *Reply) ([]int64, error) {
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]int64, len(multi))
+	for i, item := range multi {
+		if values[i], err = item.IntegerValue(); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func hExpireValue(rp *Reply) ([]HExpireResult, error) {
+	values, err := hIntegersValue(rp)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]HExpireResult, len(values))
+	for i, v := range values {
+		results[i] = HExpireResult(v)
+	}
+	return results, nil
+}
+
+func hFieldState(v int64) HFieldState {
+	switch v {
+	case -2:
+		return HFieldNoField
+	case -1:
+		return HFieldPersistent
+	}
+	return HFieldExpiring
+}
+
+func hTTLValue(rp *Reply, unit time.Duration) ([]HFieldTTL, error) {
+	values, err := hIntegersValue(rp)
+	if err != nil {
+		return nil, err
+	}
+	ttls := make([]HFieldTTL, len(values))
+	for i, v := range values {
+		if ttls[i].State = hFieldState(v); ttls[i].State == HFieldExpiring {
+			ttls[i].TTL = time.Duration(v) * unit
+		}
+	}
+	return ttls, nil
+}
+
+func hExpireTimeValue(rp *Reply, unit time.Duration) ([]HFieldExpireTime, error) {
+	values, err := hIntegersValue(rp)
+	if err != nil {
+		return nil, err
+	}
+	times := make([]HFieldExpireTime, len(values))
+	for i, v := range values {
+		if times[i].State = hFieldState(v); times[i].State == HFieldExpiring {
+			times[i].Time = time.UnixMilli(v * int64(unit/time.Millisecond))
+		}
+	}
+	return times, nil
+}
+
+// HEXPIRE key seconds [NX|XX|GT|LT] FIELDS numfields field [field ...]
+// HExpire sets the time to live of fields of the hash stored at key, in
+// seconds, with HPEXPIRE when ttl is not whole seconds. Returns a result per
+// field.
+// Redis 7.4+
+func (r *Redis) HExpire(key string, ttl time.Duration, cond ExpireCondition, fields ...string) ([]HExpireResult, error) {
+	args := hFieldsArgs(ttlArgs("HEXPIRE", "HPEXPIRE", key, ttl, cond), fields)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return hExpireValue(rp)
+}
+
+// HPEXPIRE key milliseconds [NX|XX|GT|LT] FIELDS numfields field [field ...]
+// HPExpire is HExpire in milliseconds.
+// Redis 7.4+
+func (r *Redis) HPExpire(key string, ttl time.Duration, cond ExpireCondition, fields ...string) ([]HExpireResult, error) {
+	args := hFieldsArgs(expireArgs("HPEXPIRE", key, milliseconds(ttl), cond), fields)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return hExpireValue(rp)
+}
+
+// HEXPIREAT key unix-time-seconds [NX|XX|GT|LT] FIELDS numfields field [field ...]
+// HExpireAt sets fields of the hash stored at key to expire at a time, in
+// seconds. Returns a result per field.
+// Redis 7.4+
+func (r *Redis) HExpireAt(key string, at time.Time, cond ExpireCondition, fields ...string) ([]HExpireResult, error) {
+	args := hFieldsArgs(expireArgs("HEXPIREAT", key, at.Unix(), cond), fields)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return hExpireValue(rp)
+}
+
+// HPEXPIREAT key unix-time-milliseconds [NX|XX|GT|LT] FIELDS numfields field [field ...]
+// HPExpireAt is HExpireAt in milliseconds.
+// Redis 7.4+
+func (r *Redis) HPExpireAt(key string, at time.Time, cond ExpireCondition, fields ...string) ([]HExpireResult, error) {
+	args := hFieldsArgs(expireArgs("HPEXPIREAT", key, at.UnixMilli(), cond), fields)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return hExpireValue(rp)
+}
+
+// HTTL key FIELDS numfields field [field ...]
+// HTTL returns the time to live of fields of the hash stored at key, in
+// seconds.
+// Redis 7.4+
+func (r *Redis) HTTL(key string, fields ...string) ([]HFieldTTL, error) {
+	rp, err := r.ExecuteCommand(hFieldsArgs([]interface{}{"HTTL", key}, fields)...)
+	if err != nil {
+		return nil, err
+	}
+	return hTTLValue(rp, time.Second)
+}
+
+// HPTTL key FIELDS numfields field [field ...]
+// HPTTL is HTTL in milliseconds.
+// Redis 7.4+
+func (r *Redis) HPTTL(key string, fields ...string) ([]HFieldTTL, error) {
+	rp, err := r.ExecuteCommand(hFieldsArgs([]interface{}{"HPTTL", key}, fields)...)
+	if err != nil {
+		return nil, err
+	}
+	return hTTLValue(rp, time.Millisecond)
+}
+
+// HEXPIRETIME key FIELDS numfields field [field ...]
+// HExpireTime returns when fields of the hash stored at key expire, in
+// seconds.
+// Redis 7.4+
+func (r *Redis) HExpireTime(key string, fields ...string) ([]HFieldExpireTime, error) {
+	rp, err := r.ExecuteCommand(hFieldsArgs([]interface{}{"HEXPIRETIME", key}, fields)...)
+	if err != nil {
+		return nil, err
+	}
+	return hExpireTimeValue(rp, time.Second)
+}
+
+// HPEXPIRETIME key FIELDS numfields field [field ...]
+// HPExpireTime is HExpireTime in milliseconds.
+// Redis 7.4+
+func (r *Redis) HPExpireTime(key string, fields ...string) ([]HFieldExpireTime, error) {
+	rp, err := r.ExecuteCommand(hFieldsArgs([]interface{}{"HPEXPIRETIME", key}, fields)...)
+	if err != nil {
+		return nil, err
+	}
+	return hExpireTimeValue(rp, time.Millisecond)
+}
+
+// HPERSIST key FIELDS numfields field [field ...]
+// HPersist removes the expiry of fields of the hash stored at key. Returns a
+// result per field.
+// Redis 7.4+
+func (r *Redis) HPersist(key string, fields ...string) ([]HPersistResult, error) {
+	rp, err := r.ExecuteCommand(hFieldsArgs([]interface{}{"HPERSIST", key}, fields)...)
+	if err != nil {
+		return nil, err
+	}
+	values, err := hIntegersValue(rp)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]HPersistResult, len(values))
+	for i, v := range values {
+		results[i] = HPersistResult(v)
+	}
+	return results, nil
+}
+
+// HGETDEL key FIELDS numfields field [field ...]
+// HGetDel returns the values of fields of the hash stored at key and deletes
+// them, nil for the fields which do not exist. The key is deleted with its
+// last field.
+// Redis 8.0+
+func (r *Redis) HGetDel(key string, fields ...string) ([][]byte, error) {
+	rp, err := r.ExecuteCommand(hFieldsArgs([]interface{}{"HGETDEL", key}, fields)...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesArrayValue()
+}
+
+// HGETEX key [EX seconds|PX milliseconds|EXAT unix-time-seconds|PXAT unix-time-milliseconds|PERSIST] FIELDS numfields field [field ...]
+// HGetEx returns the values of fields of the hash stored at key and sets or
+// removes their expiry, nil for the fields which do not exist.
+// Redis 8.0+
+func (r *Redis) HGetEx(key string, opts GetExOptions, fields ...string) ([][]byte, error) {
+	args, err := opts.args([]interface{}{"HGETEX", key})
+	if err != nil {
+		return nil, err
+	}
+	rp, err := r.ExecuteCommand(hFieldsArgs(args, fields)...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesArrayValue()
+}
+
+// HSetExOptions represents options for HSETEX command
+type HSetExOptions struct {
+	FNX     bool          // FNX option - only set if none of the fields exist
+	FXX     bool          // FXX option - only set if all the fields exist
+	EX      time.Duration // EX option - expire after, in seconds, sent as PX when not whole seconds
+	PX      time.Duration // PX option - expire after, in milliseconds
+	EXAT    time.Time     // EXAT option - expire at, in unix seconds
+	PXAT    time.Time     // PXAT option - expire at, in unix milliseconds
+	KeepTTL bool          // KEEPTTL option - keep the expiry of the fields
+}
+
+// HSETEX key [FNX|FXX] [EX seconds|PX milliseconds|EXAT unix-time-seconds|PXAT unix-time-milliseconds|KEEPTTL] FIELDS numfields field value [field value ...]
+// HSetEx sets fields of the hash stored at key with an expiry. Returns false
+// if the FNX or FXX condition prevented setting them, no field is set then.
+// Redis 8.0+
+func (r *Redis) HSetEx(key string, pairs map[string]string, opts HSetExOptions) (bool, error) {
+	args := []interface{}{"HSETEX", key}
+	if opts.FNX && opts.FXX {
+		return false, errors.New("FNX and FXX are mutually exclusive")
+	}
+	if opts.FNX {
+		args = append(args, "FNX")
+	}
+	if opts.FXX {
+		args = append(args, "FXX")
+	}
+	args, err := expiryArgs(args, opts.EX, opts.PX, opts.EXAT, opts.PXAT, "KEEPTTL", opts.KeepTTL)
+	if err != nil {
+		return false, err
+	}
+	args = append(args, "FIELDS", len(pairs))
+	for field, value := range pairs {
+		args = append(args, field, value)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// HScanNoValues is HScan returning only the field names.
+// HSCAN key cursor [MATCH pattern] [COUNT count] NOVALUES
+// Redis 7.4+
+func (r *Redis) HScanNoValues(key string, cursor uint64, pattern string, count int) (uint64, []string, error) {
+	args := packArgs("HSCAN", key, cursor)
+	if pattern != "" {
+		args = append(args, "MATCH", pattern)
+	}
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+	rp, err := r.ExecuteCommand(append(args, "NOVALUES")...)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rp.Multi) != 2 {
+		return 0, nil, errors.New("scan protocol error")
+	}
+	first, err := rp.Multi[0].StringValue()
+	if err != nil {
+		return 0, nil, err
+	}
+	next, err := strconv.ParseUint(first, 10, 64)
+	if err != nil {
+		return 0, nil, err
+	}
+	fields, err := rp.Multi[1].ListValue()
+	return next, fields, err
+}
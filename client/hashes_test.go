@@ -1,7 +1,9 @@
 package client
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestHDel(t *testing.T) {
@@ -211,3 +213,111 @@ func TestHRandField(t *testing.T) {
 		t.Error("Expected empty field for empty hash")
 	}
 }
+
+func TestHExpire(t *testing.T) {
+	addr, commands := serveReplies(t,
+		"*3\r\n:1\r\n:0\r\n:-2\r\n",
+		"*3\r\n:1500\r\n:-1\r\n:-2\r\n",
+		"*2\r\n:1700000000\r\n:-1\r\n",
+		"*2\r\n:1\r\n:-1\r\n",
+		"*1\r\n:1\r\n",
+	)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	results, err := rr.HExpire("session", time.Minute, ExpireNX, "a", "b", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "HEXPIRE session 60 NX FIELDS 3 a b c" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if len(results) != 3 || results[0] != HExpireSet || results[1] != HExpireNotSet || results[2] != HExpireNoField {
+		t.Errorf("unexpected results %v", results)
+	}
+	ttls, err := rr.HPTTL("session", "a", "b", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "HPTTL session FIELDS 3 a b c" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	expected := []HFieldTTL{{HFieldExpiring, 1500 * time.Millisecond}, {HFieldPersistent, 0}, {HFieldNoField, 0}}
+	if len(ttls) != 3 || ttls[0] != expected[0] || ttls[1] != expected[1] || ttls[2] != expected[2] {
+		t.Errorf("unexpected ttls %v", ttls)
+	}
+	times, err := rr.HExpireTime("session", "a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-commands
+	if len(times) != 2 || !times[0].Time.Equal(time.Unix(1700000000, 0)) || times[1].State != HFieldPersistent {
+		t.Errorf("unexpected expire times %v", times)
+	}
+	persisted, err := rr.HPersist("session", "a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-commands
+	if len(persisted) != 2 || persisted[0] != HPersistPersisted || persisted[1] != HPersistNoExpiry {
+		t.Errorf("unexpected results %v", persisted)
+	}
+	if _, err := rr.HExpire("session", 500*time.Millisecond, ExpireAlways, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "HPEXPIRE session 500 FIELDS 1 a" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+}
+
+func TestHGetEx(t *testing.T) {
+	addr, commands := serveReplies(t, "*2\r\n$1\r\n1\r\n$-1\r\n", ":1\r\n", ":1\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	values, err := rr.HGetEx("session", GetExOptions{PX: 1500 * time.Millisecond}, "a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "HGETEX session PX 1500 FIELDS 2 a b" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if len(values) != 2 || string(values[0]) != "1" || values[1] != nil {
+		t.Errorf("unexpected values %q", values)
+	}
+	ok, err := rr.HSetEx("session", map[string]string{"a": "2"}, HSetExOptions{FXX: true, KeepTTL: true})
+	if err != nil || !ok {
+		t.Errorf("unexpected result %v, %v", ok, err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "HSETEX session FXX KEEPTTL FIELDS 1 a 2" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if _, err := rr.HSetEx("session", map[string]string{"a": "2"}, HSetExOptions{EX: 500 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "HSETEX session PX 500 FIELDS 1 a 2" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if _, err := rr.HSetEx("session", map[string]string{"a": "2"}, HSetExOptions{EX: time.Second, KeepTTL: true}); err == nil {
+		t.Error("expected an error for EX with KEEPTTL")
+	}
+}
+
+func TestHIncrByFloatRESP3(t *testing.T) {
+	addr, _ := serveReplies(t, ",3.5\r\n", "$3\r\n4.5\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	// RESP3 double, then RESP2 bulk string
+	for _, expected := range []float64{3.5, 4.5} {
+		if f, err := rr.HIncrByFloat("key", "field", 1); err != nil || f != expected {
+			t.Errorf("expected %v, got %v %v", expected, f, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"errors"
 	"strconv"
+	"time"
 )
 
 // Del removes the specified keys.
@@ -59,6 +60,90 @@ func (r *Redis) ExpireAt(key string, timestamp int64) (bool, error) {
 	return rp.BoolValue()
 }
 
+// ExpireCondition restricts when a new expiry is set on a key. Redis 7.0+
+type ExpireCondition string
+
+// Expire conditions
+const (
+	ExpireAlways ExpireCondition = ""   // set the expiry unconditionally
+	ExpireNX     ExpireCondition = "NX" // only when the key has no expiry
+	ExpireXX     ExpireCondition = "XX" // only when the key has an expiry
+	ExpireGT     ExpireCondition = "GT" // only when the new expiry is later
+	ExpireLT     ExpireCondition = "LT" // only when the new expiry is sooner
+)
+
+// expireArgs packs an EXPIRE family command with its condition
+func expireArgs(cmd, key string, value int64, cond ExpireCondition) []interface{} {
+	args := []interface{}{cmd, key, value}
+	if cond != ExpireAlways {
+		args = append(args, string(cond))
+	}
+	return args
+}
+
+// ttlArgs packs cmd, an EXPIRE family command taking seconds, or pcmd, its
+// milliseconds variant, when ttl is not whole seconds which cmd would
+// truncate
+func ttlArgs(cmd, pcmd, key string, ttl time.Duration, cond ExpireCondition) []interface{} {
+	if ttl%time.Second != 0 {
+		return expireArgs(pcmd, key, milliseconds(ttl), cond)
+	}
+	return expireArgs(cmd, key, int64(ttl/time.Second), cond)
+}
+
+// ExpireWithOptions sets the time to live of key if cond is met, with
+// PEXPIRE when ttl is not whole seconds. False if the key does not exist or
+// cond was not met.
+func (r *Redis) ExpireWithOptions(key string, ttl time.Duration, cond ExpireCondition) (bool, error) {
+	rp, err := r.ExecuteCommand(ttlArgs("EXPIRE", "PEXPIRE", key, ttl, cond)...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// ExpireAtWithOptions sets key to expire at the given time, truncated to
+// seconds, if cond is met. False if the key does not exist or cond was not
+// met.
+func (r *Redis) ExpireAtWithOptions(key string, at time.Time, cond ExpireCondition) (bool, error) {
+	rp, err := r.ExecuteCommand(expireArgs("EXPIREAT", key, at.Unix(), cond)...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// ExpireTime returns the time at which key will expire, truncated to
+// seconds. ErrNil is returned if the key does not exist, ErrNoExpiry if it
+// has no expiry. Redis 7.0+
+func (r *Redis) ExpireTime(key string) (time.Time, error) {
+	rp, err := r.ExecuteCommand("EXPIRETIME", key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	n, err := expiryValue(rp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(n, 0), nil
+}
+
+// expiryValue reads the reply of TTL and EXPIRETIME commands, which are
+// negative for a missing key or a key without expiry
+func expiryValue(rp *Reply) (int64, error) {
+	n, err := rp.IntegerValue()
+	if err != nil {
+		return 0, err
+	}
+	switch n {
+	case -2:
+		return 0, ErrNil
+	case -1:
+		return 0, ErrNoExpiry
+	}
+	return n, nil
+}
+
 // Keys returns all keys matching pattern.
 func (r *Redis) Keys(pattern string) ([]string, error) {
 	rp, err := r.ExecuteCommand("KEYS", pattern)
@@ -68,36 +153,59 @@ func (r *Redis) Keys(pattern string) ([]string, error) {
 	return rp.ListValue()
 }
 
-// Atomically transfer a key from a source Redis instance to a destination Redis instance.
-// On success the key is deleted from the original instance and is guaranteed to exist in the target instance.
-//
-// The command is atomic and blocks the two instances for the time required to transfer the key,
-// at any given time the key will appear to exist in a given instance or in the other instance,
-// unless a timeout error occurs.
-//
-// The timeout specifies the maximum idle time in any moment of the communication
-// with the destination instance in milliseconds.
-//
-// COPY -- Do not remove the key from the local instance.
-// REPLACE -- Replace existing key on the remote instance.
-//
-// Status code reply: The command returns OK on success.
-// MIGRATE host port key destination-db timeout [COPY] [REPLACE]
+// MigrateOptions represents options for MIGRATE command
+type MigrateOptions struct {
+	Copy     bool   // COPY option - do not remove the keys from the source
+	Replace  bool   // REPLACE option - replace existing keys on the destination
+	Username string // AUTH2 username, used with Password. Redis 6.0+
+	Password string // AUTH password of the destination
+}
+
+// Migrate atomically transfers keys from this instance to the instance at
+// host:port, database db. On success the keys are deleted from this instance
+// unless Copy is set. The command blocks both instances for the time of the
+// transfer, timeout is the longest idle time of the communication with the
+// destination.
 //
-// func (r *Redis) Migrate(host, port, key string, db, timeout int, cp, replace bool) error {
-// 	args := packArgs("MIGRATE", host, port, key, db, timeout)
-// 	if cp {
-// 		args = append(args, "COPY")
-// 	}
-// 	if replace {
-// 		args = append(args, "REPLACE")
-// 	}
-// 	rp, err := r.ExecuteCommand(args...)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	return rp.OKValue()
-// }
+// Several keys are sent with the KEYS form of MIGRATE (Redis 3.0.6+).
+// False is returned if none of the keys exist, the destination rejecting a
+// key it already holds without Replace is an error.
+func (r *Redis) Migrate(host string, port int, keys []string, db int, timeout time.Duration, opts MigrateOptions) (bool, error) {
+	if len(keys) == 0 {
+		return false, errors.New("no keys to migrate")
+	}
+	key := keys[0]
+	if len(keys) > 1 {
+		key = ""
+	}
+	args := []interface{}{"MIGRATE", host, port, key, db, int64(timeout / time.Millisecond)}
+	if opts.Copy {
+		args = append(args, "COPY")
+	}
+	if opts.Replace {
+		args = append(args, "REPLACE")
+	}
+	if opts.Username != "" {
+		args = append(args, "AUTH2", opts.Username, opts.Password)
+	} else if opts.Password != "" {
+		args = append(args, "AUTH", opts.Password)
+	}
+	if len(keys) > 1 {
+		args = append(args, "KEYS")
+		for _, k := range keys {
+			args = append(args, k)
+		}
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return false, err
+	}
+	status, err := rp.StatusValue()
+	if err != nil {
+		return false, err
+	}
+	return status != "NOKEY", nil
+}
 
 // Move moves key from the currently selected database (see SELECT)
 // to the specified destination database.
@@ -154,15 +262,54 @@ func (r *Redis) PExpireAt(key string, timestamp int64) (bool, error) {
 	return rp.BoolValue()
 }
 
+// PExpireWithOptions sets the time to live of key, truncated to
+// milliseconds but at least one, if cond is met. False if the key does not
+// exist or cond was not met.
+func (r *Redis) PExpireWithOptions(key string, ttl time.Duration, cond ExpireCondition) (bool, error) {
+	rp, err := r.ExecuteCommand(expireArgs("PEXPIRE", key, milliseconds(ttl), cond)...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// PExpireAtWithOptions sets key to expire at the given time, truncated to
+// milliseconds, if cond is met. False if the key does not exist or cond was
+// not met.
+func (r *Redis) PExpireAtWithOptions(key string, at time.Time, cond ExpireCondition) (bool, error) {
+	rp, err := r.ExecuteCommand(expireArgs("PEXPIREAT", key, at.UnixMilli(), cond)...)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// PExpireTime returns the time at which key will expire, truncated to
+// milliseconds. ErrNil is returned if the key does not exist, ErrNoExpiry
+// if it has no expiry. Redis 7.0+
+func (r *Redis) PExpireTime(key string) (time.Time, error) {
+	rp, err := r.ExecuteCommand("PEXPIRETIME", key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	n, err := expiryValue(rp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(n), nil
+}
+
 // PTTL returns the remaining time to live of a key that has an expire set,
 // with the sole difference that TTL returns the amount of remaining time in seconds
 // while PTTL returns it in milliseconds.
-func (r *Redis) PTTL(key string) (int64, error) {
+// ErrNil is returned if the key does not exist, ErrNoExpiry if it has no expiry.
+func (r *Redis) PTTL(key string) (time.Duration, error) {
 	rp, err := r.ExecuteCommand("PTTL", key)
 	if err != nil {
 		return 0, err
 	}
-	return rp.IntegerValue()
+	n, err := expiryValue(rp)
+	return time.Duration(n) * time.Millisecond, err
 }
 
 // RandomKey returns a random key from the currently selected database.
@@ -210,14 +357,15 @@ func (r *Redis) Restore(key string, ttl int, serialized string) error {
 	return rp.OKValue()
 }
 
-// TTL returns the remaining time to live of a key that has a timeout.
-// Integer reply: TTL in seconds, or a negative value in order to signal an error (see the description above).
-func (r *Redis) TTL(key string) (int64, error) {
+// TTL returns the remaining time to live of a key that has a timeout, in seconds.
+// ErrNil is returned if the key does not exist, ErrNoExpiry if it has no expiry.
+func (r *Redis) TTL(key string) (time.Duration, error) {
 	rp, err := r.ExecuteCommand("TTL", key)
 	if err != nil {
 		return 0, err
 	}
-	return rp.IntegerValue()
+	n, err := expiryValue(rp)
+	return time.Duration(n) * time.Second, err
 }
 
 // Type returns the string representation of the type of the value stored at key.
@@ -246,7 +394,7 @@ func (r *Redis) Scan(cursor uint64, pattern string, count int) (uint64, []string
 		return 0, nil, err
 	}
 	if rp.Type == ErrorReply {
-		return 0, nil, errors.New(rp.Error)
+		return 0, nil, newRedisError(rp.Error)
 	}
 	if rp.Type != MultiReply {
 		return 0, nil, errors.New("scan protocol error")
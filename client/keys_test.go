@@ -1,6 +1,7 @@
 package client
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -45,7 +46,7 @@ func TestExpire(t *testing.T) {
 	}
 	if n, err := r.TTL("key"); err != nil {
 		t.Error(err)
-	} else if n != 10 {
+	} else if n != 10*time.Second {
 		t.Fail()
 	}
 }
@@ -324,3 +325,68 @@ func TestWait(t *testing.T) {
 		t.Error("Expected non-negative number of synced replicas")
 	}
 }
+
+func TestExpireWithOptions(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	addr, commands := serveReplies(t, ":1\r\n", ":1\r\n", ":1\r\n", ":0\r\n", ":1\r\n", ":1\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	if b, err := rr.ExpireWithOptions("key", 90*time.Second, ExpireNX); err != nil || !b {
+		t.Errorf("ExpireWithOptions: %v, %v", b, err)
+	}
+	if b, err := rr.ExpireWithOptions("key", 500*time.Millisecond, ExpireAlways); err != nil || !b {
+		t.Errorf("ExpireWithOptions: %v, %v", b, err)
+	}
+	// PEXPIRE 0 would delete the key
+	if b, err := rr.ExpireWithOptions("key", 500*time.Microsecond, ExpireAlways); err != nil || !b {
+		t.Errorf("ExpireWithOptions: %v, %v", b, err)
+	}
+	if b, err := rr.PExpireWithOptions("key", 1500*time.Millisecond, ExpireGT); err != nil || b {
+		t.Errorf("PExpireWithOptions: %v, %v", b, err)
+	}
+	if b, err := rr.ExpireAtWithOptions("key", at, ExpireAlways); err != nil || !b {
+		t.Errorf("ExpireAtWithOptions: %v, %v", b, err)
+	}
+	if b, err := rr.PExpireAtWithOptions("key", at, ExpireLT); err != nil || !b {
+		t.Errorf("PExpireAtWithOptions: %v, %v", b, err)
+	}
+	for _, expected := range []string{
+		"EXPIRE key 90 NX",
+		"PEXPIRE key 500",
+		"PEXPIRE key 1",
+		"PEXPIRE key 1500 GT",
+		"EXPIREAT key 1700000000",
+		"PEXPIREAT key 1700000000000 LT",
+	} {
+		if cmd := strings.Join(<-commands, " "); cmd != expected {
+			t.Errorf("expected %q, got %q", expected, cmd)
+		}
+	}
+}
+
+func TestTTLSentinels(t *testing.T) {
+	addr, _ := serveReplies(t, ":-2\r\n", ":-1\r\n", ":1500\r\n", ":-1\r\n", ":1700000000\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	if _, err := rr.TTL("missing"); err != ErrNil {
+		t.Errorf("expected ErrNil, got %v", err)
+	}
+	if _, err := rr.TTL("persistent"); err != ErrNoExpiry {
+		t.Errorf("expected ErrNoExpiry, got %v", err)
+	}
+	if d, err := rr.PTTL("key"); err != nil || d != 1500*time.Millisecond {
+		t.Errorf("PTTL: %v, %v", d, err)
+	}
+	if _, err := rr.ExpireTime("persistent"); err != ErrNoExpiry {
+		t.Errorf("expected ErrNoExpiry, got %v", err)
+	}
+	if at, err := rr.ExpireTime("key"); err != nil || !at.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("ExpireTime: %v, %v", at, err)
+	}
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultCopyKeysConcurrency is the default number of keys CopyKeys copies
+// at once
+const DefaultCopyKeysConcurrency = 8
+
+// CopyKeysOptions represents options for CopyKeys
+type CopyKeysOptions struct {
+	// Concurrency is the number of keys copied at once,
+	// DefaultCopyKeysConcurrency when 0
+	Concurrency int
+	// ScanCount is the COUNT hint of the SCAN of src
+	ScanCount int
+	// Replace overwrites keys which exist on dst, otherwise they are skipped
+	Replace bool
+	// Progress, when set, is called with the totals after each key is
+	// handled. Calls are not concurrent but come from several goroutines.
+	Progress func(CopyKeysProgress)
+}
+
+// CopyKeysProgress counts the keys handled by CopyKeys
+type CopyKeysProgress struct {
+	Scanned int64 // keys returned by the SCAN of src
+	Copied  int64 // keys restored on dst
+	Skipped int64 // keys which expired before being copied, or existed on dst
+}
+
+// CopyKeys copies the keys of src matching pattern to dst, with DUMP and
+// RESTORE, keeping their time to live. Unlike Migrate it works between
+// instances which cannot reach each other or use different credentials, such
+// as separate clusters. With a ClusterClient as src every master is scanned.
+//
+// Keys are copied by opts.Concurrency goroutines while src is scanned. The
+// first error stops the copy and is returned with the totals so far. The
+// context of src, see WithContext, stops the copy when done.
+func CopyKeys(src, dst *Redis, pattern string, opts *CopyKeysOptions) (CopyKeysProgress, error) {
+	var o CopyKeysOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultCopyKeysConcurrency
+	}
+	ctx, cancel := context.WithCancel(src.Context())
+	defer cancel()
+	src, dst = src.WithContext(ctx), dst.WithContext(ctx)
+
+	var (
+		mu       sync.Mutex
+		progress CopyKeysProgress
+		firstErr error
+	)
+	keys := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < o.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keys {
+				if ctx.Err() != nil {
+					continue
+				}
+				copied, err := copyKey(src, dst, key, o.Replace)
+				mu.Lock()
+				switch {
+				case err != nil:
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				case copied:
+					progress.Copied++
+				default:
+					progress.Skipped++
+				}
+				if err == nil && o.Progress != nil {
+					o.Progress(progress)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	scan := func(node *Redis) error {
+		it := node.ScanIter(&ScanOptions{Match: pattern, Count: o.ScanCount})
+		for it.Next() {
+			mu.Lock()
+			progress.Scanned++
+			mu.Unlock()
+			select {
+			case keys <- it.Val():
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return it.Err()
+	}
+	var err error
+	if src.cluster != nil {
+		err = src.cluster.ForEachMaster(func(node *Redis) error {
+			return scan(node.WithContext(ctx))
+		})
+	} else {
+		err = scan(src)
+	}
+	close(keys)
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if firstErr != nil {
+		return progress, firstErr
+	}
+	return progress, err
+}
+
+// copyKey copies a key with DUMP and RESTORE, returning false if it expired
+// or exists on dst and replace is not set
+func copyKey(src, dst *Redis, key string, replace bool) (bool, error) {
+	pipe := src.Pipeline()
+	dump := pipe.Dump(key)
+	pttl := pipe.PTTL(key)
+	if err := pipe.Exec(); err != nil {
+		return false, err
+	}
+	payload, err := dump.Result()
+	if err != nil || payload == nil {
+		return false, err
+	}
+	ttl, err := pttl.Result()
+	switch {
+	case err == ErrNoExpiry:
+		ttl = 0
+	case err == ErrNil:
+		return false, nil
+	case err != nil:
+		return false, err
+	case ttl <= 0:
+		// RESTORE would make an expiring key persistent
+		return false, nil
+	}
+	args := []interface{}{"RESTORE", key, int64(ttl / time.Millisecond), payload}
+	if replace {
+		args = append(args, "REPLACE")
+	}
+	rp, err := dst.ExecuteCommand(args...)
+	if errors.Is(err, ErrBusyKey) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, rp.OKValue()
+}
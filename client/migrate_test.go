@@ -0,0 +1,74 @@
+package client
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMigrate(t *testing.T) {
+	addr, commands := serveReplies(t, "+OK\r\n", "+NOKEY\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	if ok, err := rr.Migrate("10.0.0.2", 6379, []string{"key"}, 0, time.Second, MigrateOptions{Copy: true, Password: "secret"}); err != nil || !ok {
+		t.Errorf("Migrate: %v, %v", ok, err)
+	}
+	if ok, err := rr.Migrate("10.0.0.2", 6379, []string{"a", "b"}, 1, 500*time.Millisecond, MigrateOptions{Replace: true, Username: "user", Password: "secret"}); err != nil || ok {
+		t.Errorf("Migrate of missing keys: %v, %v", ok, err)
+	}
+	for _, expected := range []string{
+		"MIGRATE 10.0.0.2 6379 key 0 1000 COPY AUTH secret",
+		"MIGRATE 10.0.0.2 6379  1 500 REPLACE AUTH2 user secret KEYS a b",
+	} {
+		if cmd := strings.Join(<-commands, " "); cmd != expected {
+			t.Errorf("expected %q, got %q", expected, cmd)
+		}
+	}
+}
+
+func TestCopyKeys(t *testing.T) {
+	srcAddr, srcCommands := serveReplies(t,
+		"*2\r\n$1\r\n0\r\n*3\r\n$1\r\na\r\n$1\r\nb\r\n$1\r\nc\r\n",
+		"$4\r\nabcd\r\n", ":-1\r\n", // a has no expiry
+		"$3\r\nxyz\r\n", ":5000\r\n", // b exists on dst
+		"$-1\r\n", ":-2\r\n", // c expired
+	)
+	dstAddr, dstCommands := serveReplies(t, "+OK\r\n", "-BUSYKEY Target key name already exists.\r\n")
+	src, err := DialWithConfig(&DialConfig{Address: srcAddr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.ClosePool()
+	dst, err := DialWithConfig(&DialConfig{Address: dstAddr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.ClosePool()
+
+	var calls int32
+	progress, err := CopyKeys(src, dst, "*", &CopyKeysOptions{
+		Concurrency: 1,
+		Progress:    func(CopyKeysProgress) { atomic.AddInt32(&calls, 1) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress != (CopyKeysProgress{Scanned: 3, Copied: 1, Skipped: 2}) {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 progress calls, got %d", calls)
+	}
+	if cmd := strings.Join(<-srcCommands, " "); cmd != "SCAN 0 MATCH *" {
+		t.Errorf("unexpected scan %q", cmd)
+	}
+	for _, expected := range []string{"RESTORE a 0 abcd", "RESTORE b 5000 xyz"} {
+		if cmd := strings.Join(<-dstCommands, " "); cmd != expected {
+			t.Errorf("expected %q, got %q", expected, cmd)
+		}
+	}
+}
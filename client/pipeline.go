@@ -0,0 +1,305 @@
+package client
+
+//go:generate go run ../internal/genpipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// errQueued is returned by ExecuteCommand while a Pipeline records a
+	// command
+	errQueued = errors.New("command queued")
+
+	errNotExecuted    = errors.New("pipeline not executed")
+	errNotPipelinable = errors.New("command cannot be pipelined")
+)
+
+// pipelineCapture takes the place of the connection in ExecuteCommand while a
+// Pipeline records a command, and while it decodes the reply to it
+type pipelineCapture struct {
+	args   []interface{}
+	calls  int
+	replay bool
+	reply  *Reply
+}
+
+func (c *pipelineCapture) execute(args []interface{}) (*Reply, error) {
+	c.calls++
+	if !c.replay {
+		c.args = args
+		return nil, errQueued
+	}
+	if c.calls > 1 {
+		return nil, errNotPipelinable
+	}
+	if c.reply.Type == ErrorReply {
+		return c.reply, newRedisError(c.reply.Error)
+	}
+	return c.reply, nil
+}
+
+// queuedCmd is a command waiting in a Pipeline
+type queuedCmd interface {
+	Args() []interface{}
+	settle(r *Redis, rp *Reply, err error)
+}
+
+// Cmd is the deferred result of a command queued on a Pipeline.
+// Its value and error are set when the pipeline is executed; until then Err
+// returns an error.
+type Cmd[T any] struct {
+	args   []interface{}
+	decode func(r *Redis) (T, error)
+	val    T
+	err    error
+}
+
+// Cmd types of the common result types
+type (
+	// StatusCmd is the result of a command returning only an error
+	StatusCmd        = Cmd[struct{}]
+	ReplyCmd         = Cmd[*Reply]
+	IntCmd           = Cmd[int64]
+	BoolCmd          = Cmd[bool]
+	FloatCmd         = Cmd[float64]
+	StringCmd        = Cmd[string]
+	BytesCmd         = Cmd[[]byte]
+	StringSliceCmd   = Cmd[[]string]
+	BytesSliceCmd    = Cmd[[][]byte]
+	BoolSliceCmd     = Cmd[[]bool]
+	IntSliceCmd      = Cmd[[]int64]
+	FloatSliceCmd    = Cmd[[]float64]
+	StringMapCmd     = Cmd[map[string]string]
+	InterfaceMapCmd  = Cmd[map[string]interface{}]
+	ZMemberSliceCmd  = Cmd[[]ZMember]
+	StreamEntriesCmd = Cmd[[]StreamEntry]
+)
+
+// Args returns the command arguments
+func (cmd *Cmd[T]) Args() []interface{} {
+	return cmd.args
+}
+
+// Val returns the result, the zero value if the command failed
+func (cmd *Cmd[T]) Val() T {
+	return cmd.val
+}
+
+// Err returns the error of the command, which is a *RedisError for an error
+// reply
+func (cmd *Cmd[T]) Err() error {
+	return cmd.err
+}
+
+// Result returns the result and error of the command
+func (cmd *Cmd[T]) Result() (T, error) {
+	return cmd.val, cmd.err
+}
+
+// settle sets the result from the reply, or from err when no reply was read.
+// The reply is decoded by the same method the command was queued with.
+func (cmd *Cmd[T]) settle(r *Redis, rp *Reply, err error) {
+	if rp == nil {
+		cmd.err = err
+		return
+	}
+	rr := *r
+	rr.capture = &pipelineCapture{replay: true, reply: rp}
+	cmd.val, cmd.err = cmd.decode(&rr)
+}
+
+// Pipeline queues commands and sends them in a single round trip on Exec.
+// It has the command methods of Redis, each returning a Cmd whose result is
+// set by Exec:
+//
+//	pipe := client.Pipeline()
+//	incr := pipe.Incr("counter")
+//	user := pipe.HGetAll("user:1")
+//	if err := pipe.Exec(); err != nil {
+//		// the connection failed, commands without a reply carry err
+//	}
+//	n, err := incr.Result()
+//
+// Error replies are reported by the Cmd of the failing command and do not
+// affect the others. Commands whose method needs more than one round trip
+// cannot be queued and fail with an error.
+//
+// A pipeline is not a transaction, other clients' commands may run between
+// the queued commands. Pipelines are not retried. On a ClusterClient the
+// commands are grouped by node, on a SentinelClient they are sent to the
+// master. A Pipeline must not be used concurrently.
+type Pipeline struct {
+	redis *Redis
+	cmds  []queuedCmd
+}
+
+// Pipeline returns an empty pipeline
+func (r *Redis) Pipeline() *Pipeline {
+	return &Pipeline{redis: r}
+}
+
+// queue records the command fn executes and adds it to the pipeline.
+// fn is called again on Exec to decode the reply.
+func queue[T any](p *Pipeline, fn func(r *Redis) (T, error)) *Cmd[T] {
+	cmd := &Cmd[T]{decode: fn, err: errNotExecuted}
+	capture := &pipelineCapture{}
+	rr := *p.redis
+	rr.capture = capture
+	err := func() (err error) {
+		defer func() {
+			if recover() != nil {
+				err = errNotPipelinable
+			}
+		}()
+		_, err = fn(&rr)
+		return err
+	}()
+	switch {
+	case capture.calls == 1:
+		cmd.args = capture.args
+		p.cmds = append(p.cmds, cmd)
+	case capture.calls > 1 || err == nil:
+		cmd.err = errNotPipelinable
+	default:
+		// rejected before anything was sent, such as invalid arguments
+		cmd.err = err
+	}
+	return cmd
+}
+
+// Do queues a raw command
+func (p *Pipeline) Do(args ...interface{}) *ReplyCmd {
+	return queue(p, func(r *Redis) (*Reply, error) {
+		return r.ExecuteCommand(args...)
+	})
+}
+
+// Len returns the number of queued commands
+func (p *Pipeline) Len() int {
+	return len(p.cmds)
+}
+
+// Discard removes the queued commands without sending them
+func (p *Pipeline) Discard() {
+	p.cmds = nil
+}
+
+// Exec sends the queued commands and sets their results.
+// The returned error only reports a failure to send the commands or read
+// the replies, commands left without a reply get the same error. The
+// pipeline is empty afterwards and can be reused.
+func (p *Pipeline) Exec() error {
+	cmds := p.cmds
+	p.cmds = nil
+	if len(cmds) == 0 {
+		return nil
+	}
+	ctx := p.redis.Context()
+	if p.redis.cluster != nil {
+		return p.redis.cluster.execPipeline(ctx, cmds)
+	}
+	results, err := p.redis.pipelineExec(ctx, cmds)
+	for i, cmd := range cmds {
+		cmd.settle(p.redis, results[i].reply, results[i].err)
+	}
+	return err
+}
+
+// pipelineResult is the reply to a pipelined command, or the error which
+// prevented reading it
+type pipelineResult struct {
+	reply *Reply
+	err   error
+}
+
+// pipelineExec sends cmds in one exchange, returning a result per command
+// and the transport error
+func (r *Redis) pipelineExec(ctx context.Context, cmds []queuedCmd) ([]pipelineResult, error) {
+	results := make([]pipelineResult, len(cmds))
+	var request []byte
+	var sent []int
+	for i, cmd := range cmds {
+		b, err := packCommand(cmd.Args()...)
+		if err != nil {
+			results[i].err = err
+			continue
+		}
+		request = append(request, b...)
+		sent = append(sent, i)
+	}
+	if len(sent) == 0 {
+		return results, nil
+	}
+	rps, err := r.exchange(ctx, request, len(sent))
+	for n, i := range sent {
+		if n < len(rps) {
+			results[i].reply = rps[n]
+		} else {
+			results[i].err = err
+		}
+	}
+	return results, err
+}
+
+// execPipeline sends one pipeline per node serving the keys of cmds.
+// Commands answered with MOVED, ASK, TRYAGAIN or CLUSTERDOWN are executed
+// again one by one, following the redirection.
+func (c *ClusterClient) execPipeline(ctx context.Context, cmds []queuedCmd) error {
+	batches := make(map[*Redis][]queuedCmd)
+	var firstErr error
+	for _, cmd := range cmds {
+		slot := -1
+		if key := c.commandKey(cmd.Args()); key != "" {
+			slot = HashSlot(key)
+		}
+		node, err := c.nodeForSlot(slot)
+		if err != nil {
+			cmd.settle(c.Redis, nil, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		batches[node] = append(batches[node], cmd)
+	}
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	for node, batch := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := node.pipelineExec(ctx, batch)
+			for i, cmd := range batch {
+				rp := results[i].reply
+				if rp != nil && rp.Type == ErrorReply && isRedirect(rp.Error) {
+					rp, err := c.executeCommand(ctx, cmd.Args()...)
+					cmd.settle(node, rp, err)
+					continue
+				}
+				cmd.settle(node, rp, results[i].err)
+			}
+			if err != nil {
+				mutex.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// isRedirect reports whether an error reply asks to run the command again,
+// possibly on another node
+func isRedirect(reply string) bool {
+	switch newRedisError(reply).Prefix {
+	case "MOVED", "ASK", "TRYAGAIN", "CLUSTERDOWN":
+		return true
+	}
+	return false
+}
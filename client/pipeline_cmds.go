@@ -0,0 +1,1991 @@
+// Code generated by genpipeline from the methods of Redis. DO NOT EDIT.
+
+package client
+
+import (
+	"github.com/therealbill/libredis/structures"
+	"time"
+)
+
+// ACLCat queues Redis.ACLCat on the pipeline.
+func (p *Pipeline) ACLCat() *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ACLCat() })
+}
+
+// ACLCatByCategory queues Redis.ACLCatByCategory on the pipeline.
+func (p *Pipeline) ACLCatByCategory(categoryname string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ACLCatByCategory(categoryname) })
+}
+
+// ACLDelUser queues Redis.ACLDelUser on the pipeline.
+func (p *Pipeline) ACLDelUser(usernames ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ACLDelUser(usernames...) })
+}
+
+// ACLDryRun queues Redis.ACLDryRun on the pipeline.
+func (p *Pipeline) ACLDryRun(username string, command string, args ...string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ACLDryRun(username, command, args...) })
+}
+
+// ACLGenPass queues Redis.ACLGenPass on the pipeline.
+func (p *Pipeline) ACLGenPass() *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.ACLGenPass() })
+}
+
+// ACLGenPassWithBits queues Redis.ACLGenPassWithBits on the pipeline.
+func (p *Pipeline) ACLGenPassWithBits(bits int) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.ACLGenPassWithBits(bits) })
+}
+
+// ACLGetUser queues Redis.ACLGetUser on the pipeline.
+func (p *Pipeline) ACLGetUser(username string) *Cmd[ACLUser] {
+	return queue(p, func(r *Redis) (ACLUser, error) { return r.ACLGetUser(username) })
+}
+
+// ACLList queues Redis.ACLList on the pipeline.
+func (p *Pipeline) ACLList() *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ACLList() })
+}
+
+// ACLLoad queues Redis.ACLLoad on the pipeline.
+func (p *Pipeline) ACLLoad() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ACLLoad() })
+}
+
+// ACLLog queues Redis.ACLLog on the pipeline.
+func (p *Pipeline) ACLLog() *Cmd[[]ACLLogEntry] {
+	return queue(p, func(r *Redis) ([]ACLLogEntry, error) { return r.ACLLog() })
+}
+
+// ACLLogReset queues Redis.ACLLogReset on the pipeline.
+func (p *Pipeline) ACLLogReset() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ACLLogReset() })
+}
+
+// ACLLogWithCount queues Redis.ACLLogWithCount on the pipeline.
+func (p *Pipeline) ACLLogWithCount(count int) *Cmd[[]ACLLogEntry] {
+	return queue(p, func(r *Redis) ([]ACLLogEntry, error) { return r.ACLLogWithCount(count) })
+}
+
+// ACLSave queues Redis.ACLSave on the pipeline.
+func (p *Pipeline) ACLSave() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ACLSave() })
+}
+
+// ACLSetUser queues Redis.ACLSetUser on the pipeline.
+func (p *Pipeline) ACLSetUser(username string, rules ...string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ACLSetUser(username, rules...) })
+}
+
+// ACLUsers queues Redis.ACLUsers on the pipeline.
+func (p *Pipeline) ACLUsers() *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ACLUsers() })
+}
+
+// ACLWhoAmI queues Redis.ACLWhoAmI on the pipeline.
+func (p *Pipeline) ACLWhoAmI() *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.ACLWhoAmI() })
+}
+
+// Append queues Redis.Append on the pipeline.
+func (p *Pipeline) Append(key string, value string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.Append(key, value) })
+}
+
+// AppendBytes queues Redis.AppendBytes on the pipeline.
+func (p *Pipeline) AppendBytes(key string, value []byte) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.AppendBytes(key, value) })
+}
+
+// AuthWithUser queues Redis.AuthWithUser on the pipeline.
+func (p *Pipeline) AuthWithUser(username string, password string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.AuthWithUser(username, password) })
+}
+
+// BFAdd queues Redis.BFAdd on the pipeline.
+func (p *Pipeline) BFAdd(key string, item interface{}) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.BFAdd(key, item) })
+}
+
+// BFExists queues Redis.BFExists on the pipeline.
+func (p *Pipeline) BFExists(key string, item interface{}) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.BFExists(key, item) })
+}
+
+// BFInfo queues Redis.BFInfo on the pipeline.
+func (p *Pipeline) BFInfo(key string) *InterfaceMapCmd {
+	return queue(p, func(r *Redis) (map[string]interface{}, error) { return r.BFInfo(key) })
+}
+
+// BFMAdd queues Redis.BFMAdd on the pipeline.
+func (p *Pipeline) BFMAdd(key string, items ...interface{}) *BoolSliceCmd {
+	return queue(p, func(r *Redis) ([]bool, error) { return r.BFMAdd(key, items...) })
+}
+
+// BFMExists queues Redis.BFMExists on the pipeline.
+func (p *Pipeline) BFMExists(key string, items ...interface{}) *BoolSliceCmd {
+	return queue(p, func(r *Redis) ([]bool, error) { return r.BFMExists(key, items...) })
+}
+
+// BFReserve queues Redis.BFReserve on the pipeline.
+func (p *Pipeline) BFReserve(key string, errorRate float64, capacity int64, options ...*BFReserveOptions) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.BFReserve(key, errorRate, capacity, options...) })
+}
+
+// BLMPop queues Redis.BLMPop on the pipeline.
+func (p *Pipeline) BLMPop(timeout int, keys []string, direction string) *Cmd[map[string][]string] {
+	return queue(p, func(r *Redis) (map[string][]string, error) { return r.BLMPop(timeout, keys, direction) })
+}
+
+// BLMPopWithCount queues Redis.BLMPopWithCount on the pipeline.
+func (p *Pipeline) BLMPopWithCount(timeout int, keys []string, direction string, count int) *Cmd[map[string][]string] {
+	return queue(p, func(r *Redis) (map[string][]string, error) { return r.BLMPopWithCount(timeout, keys, direction, count) })
+}
+
+// BLMove queues Redis.BLMove on the pipeline.
+func (p *Pipeline) BLMove(source string, destination string, wherefrom string, whereto string, timeout int) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.BLMove(source, destination, wherefrom, whereto, timeout) })
+}
+
+// BLPop queues Redis.BLPop on the pipeline.
+func (p *Pipeline) BLPop(keys []string, timeout int) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.BLPop(keys, timeout) })
+}
+
+// BRPop queues Redis.BRPop on the pipeline.
+func (p *Pipeline) BRPop(keys []string, timeout int) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.BRPop(keys, timeout) })
+}
+
+// BRPopLPush queues Redis.BRPopLPush on the pipeline.
+func (p *Pipeline) BRPopLPush(source string, destination string, timeout int) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.BRPopLPush(source, destination, timeout) })
+}
+
+// BZMPop queues Redis.BZMPop on the pipeline.
+func (p *Pipeline) BZMPop(timeout int, keys []string, where string, count int) *Cmd[ZPopResult] {
+	return queue(p, func(r *Redis) (ZPopResult, error) { return r.BZMPop(timeout, keys, where, count) })
+}
+
+// BZPopMax queues Redis.BZPopMax on the pipeline.
+func (p *Pipeline) BZPopMax(keys []string, timeout int) *Cmd[ZPopResult] {
+	return queue(p, func(r *Redis) (ZPopResult, error) { return r.BZPopMax(keys, timeout) })
+}
+
+// BZPopMin queues Redis.BZPopMin on the pipeline.
+func (p *Pipeline) BZPopMin(keys []string, timeout int) *Cmd[ZPopResult] {
+	return queue(p, func(r *Redis) (ZPopResult, error) { return r.BZPopMin(keys, timeout) })
+}
+
+// BgRewriteAof queues Redis.BgRewriteAof on the pipeline.
+func (p *Pipeline) BgRewriteAof() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.BgRewriteAof() })
+}
+
+// BgSave queues Redis.BgSave on the pipeline.
+func (p *Pipeline) BgSave() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.BgSave() })
+}
+
+// BitCount queues Redis.BitCount on the pipeline.
+func (p *Pipeline) BitCount(key string, start int, end int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.BitCount(key, start, end) })
+}
+
+// BitField queues Redis.BitField on the pipeline.
+func (p *Pipeline) BitField(key string, operations []BitFieldOperation) *IntSliceCmd {
+	return queue(p, func(r *Redis) ([]int64, error) { return r.BitField(key, operations) })
+}
+
+// BitFieldRO queues Redis.BitFieldRO on the pipeline.
+func (p *Pipeline) BitFieldRO(key string, getOps []BitFieldOperation) *IntSliceCmd {
+	return queue(p, func(r *Redis) ([]int64, error) { return r.BitFieldRO(key, getOps) })
+}
+
+// BitFieldWithOverflow queues Redis.BitFieldWithOverflow on the pipeline.
+func (p *Pipeline) BitFieldWithOverflow(key string, overflow BitFieldOverflow, operations []BitFieldOperation) *IntSliceCmd {
+	return queue(p, func(r *Redis) ([]int64, error) { return r.BitFieldWithOverflow(key, overflow, operations) })
+}
+
+// BitOp queues Redis.BitOp on the pipeline.
+func (p *Pipeline) BitOp(operation string, destkey string, keys ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.BitOp(operation, destkey, keys...) })
+}
+
+// BitPos queues Redis.BitPos on the pipeline.
+func (p *Pipeline) BitPos(key string, bit int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.BitPos(key, bit) })
+}
+
+// BitPosWithRange queues Redis.BitPosWithRange on the pipeline.
+func (p *Pipeline) BitPosWithRange(key string, bit int, opts BitPosOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.BitPosWithRange(key, bit, opts) })
+}
+
+// CFAdd queues Redis.CFAdd on the pipeline.
+func (p *Pipeline) CFAdd(key string, item interface{}) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.CFAdd(key, item) })
+}
+
+// CFDel queues Redis.CFDel on the pipeline.
+func (p *Pipeline) CFDel(key string, item interface{}) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.CFDel(key, item) })
+}
+
+// CFExists queues Redis.CFExists on the pipeline.
+func (p *Pipeline) CFExists(key string, item interface{}) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.CFExists(key, item) })
+}
+
+// CFInfo queues Redis.CFInfo on the pipeline.
+func (p *Pipeline) CFInfo(key string) *InterfaceMapCmd {
+	return queue(p, func(r *Redis) (map[string]interface{}, error) { return r.CFInfo(key) })
+}
+
+// CFReserve queues Redis.CFReserve on the pipeline.
+func (p *Pipeline) CFReserve(key string, capacity int64, options ...*CFReserveOptions) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.CFReserve(key, capacity, options...) })
+}
+
+// CMSIncrBy queues Redis.CMSIncrBy on the pipeline.
+func (p *Pipeline) CMSIncrBy(key string, itemIncrements ...interface{}) *IntSliceCmd {
+	return queue(p, func(r *Redis) ([]int64, error) { return r.CMSIncrBy(key, itemIncrements...) })
+}
+
+// CMSInfo queues Redis.CMSInfo on the pipeline.
+func (p *Pipeline) CMSInfo(key string) *InterfaceMapCmd {
+	return queue(p, func(r *Redis) (map[string]interface{}, error) { return r.CMSInfo(key) })
+}
+
+// CMSInitByDim queues Redis.CMSInitByDim on the pipeline.
+func (p *Pipeline) CMSInitByDim(key string, width int64, depth int64) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.CMSInitByDim(key, width, depth) })
+}
+
+// CMSInitByProb queues Redis.CMSInitByProb on the pipeline.
+func (p *Pipeline) CMSInitByProb(key string, errorRate float64, probability float64) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.CMSInitByProb(key, errorRate, probability) })
+}
+
+// CMSMerge queues Redis.CMSMerge on the pipeline.
+func (p *Pipeline) CMSMerge(destKey string, sourceKeys []string, weights ...float64) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.CMSMerge(destKey, sourceKeys, weights...) })
+}
+
+// CMSQuery queues Redis.CMSQuery on the pipeline.
+func (p *Pipeline) CMSQuery(key string, items ...interface{}) *IntSliceCmd {
+	return queue(p, func(r *Redis) ([]int64, error) { return r.CMSQuery(key, items...) })
+}
+
+// ClientGetName queues Redis.ClientGetName on the pipeline.
+func (p *Pipeline) ClientGetName() *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.ClientGetName() })
+}
+
+// ClientKill queues Redis.ClientKill on the pipeline.
+func (p *Pipeline) ClientKill(ip string, port int) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ClientKill(ip, port) })
+}
+
+// ClientList queues Redis.ClientList on the pipeline.
+func (p *Pipeline) ClientList() *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.ClientList() })
+}
+
+// ClientPause queues Redis.ClientPause on the pipeline.
+func (p *Pipeline) ClientPause(timeout uint64) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ClientPause(timeout) })
+}
+
+// ClientSetName queues Redis.ClientSetName on the pipeline.
+func (p *Pipeline) ClientSetName(name string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ClientSetName(name) })
+}
+
+// ClusterCountKeysInSlot queues Redis.ClusterCountKeysInSlot on the pipeline.
+func (p *Pipeline) ClusterCountKeysInSlot(slot int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ClusterCountKeysInSlot(slot) })
+}
+
+// ClusterGetKeysInSlot queues Redis.ClusterGetKeysInSlot on the pipeline.
+func (p *Pipeline) ClusterGetKeysInSlot(slot int, count int) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ClusterGetKeysInSlot(slot, count) })
+}
+
+// ClusterInfo queues Redis.ClusterInfo on the pipeline.
+func (p *Pipeline) ClusterInfo() *StringMapCmd {
+	return queue(p, func(r *Redis) (map[string]string, error) { return r.ClusterInfo() })
+}
+
+// ClusterKeySlot queues Redis.ClusterKeySlot on the pipeline.
+func (p *Pipeline) ClusterKeySlot(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ClusterKeySlot(key) })
+}
+
+// ClusterMyID queues Redis.ClusterMyID on the pipeline.
+func (p *Pipeline) ClusterMyID() *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.ClusterMyID() })
+}
+
+// ClusterNodes queues Redis.ClusterNodes on the pipeline.
+func (p *Pipeline) ClusterNodes() *Cmd[[]structures.ClusterNode] {
+	return queue(p, func(r *Redis) ([]structures.ClusterNode, error) { return r.ClusterNodes() })
+}
+
+// ClusterShards queues Redis.ClusterShards on the pipeline.
+func (p *Pipeline) ClusterShards() *Cmd[[]structures.ClusterShard] {
+	return queue(p, func(r *Redis) ([]structures.ClusterShard, error) { return r.ClusterShards() })
+}
+
+// Command queues Redis.Command on the pipeline.
+func (p *Pipeline) Command() *Cmd[[]structures.CommandEntry] {
+	return queue(p, func(r *Redis) ([]structures.CommandEntry, error) { return r.Command() })
+}
+
+// ConfigGet queues Redis.ConfigGet on the pipeline.
+func (p *Pipeline) ConfigGet(parameter string) *StringMapCmd {
+	return queue(p, func(r *Redis) (map[string]string, error) { return r.ConfigGet(parameter) })
+}
+
+// ConfigResetStat queues Redis.ConfigResetStat on the pipeline.
+func (p *Pipeline) ConfigResetStat() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ConfigResetStat() })
+}
+
+// ConfigRewrite queues Redis.ConfigRewrite on the pipeline.
+func (p *Pipeline) ConfigRewrite() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ConfigRewrite() })
+}
+
+// ConfigSet queues Redis.ConfigSet on the pipeline.
+func (p *Pipeline) ConfigSet(parameter string, value string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ConfigSet(parameter, value) })
+}
+
+// ConfigSetInt queues Redis.ConfigSetInt on the pipeline.
+func (p *Pipeline) ConfigSetInt(parameter string, value int) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ConfigSetInt(parameter, value) })
+}
+
+// Copy queues Redis.Copy on the pipeline.
+func (p *Pipeline) Copy(source string, destination string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.Copy(source, destination) })
+}
+
+// CopyWithOptions queues Redis.CopyWithOptions on the pipeline.
+func (p *Pipeline) CopyWithOptions(source string, destination string, opts CopyOptions) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.CopyWithOptions(source, destination, opts) })
+}
+
+// DBSize queues Redis.DBSize on the pipeline.
+func (p *Pipeline) DBSize() *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.DBSize() })
+}
+
+// DebugObject queues Redis.DebugObject on the pipeline.
+func (p *Pipeline) DebugObject(key string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.DebugObject(key) })
+}
+
+// Decr queues Redis.Decr on the pipeline.
+func (p *Pipeline) Decr(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.Decr(key) })
+}
+
+// DecrBy queues Redis.DecrBy on the pipeline.
+func (p *Pipeline) DecrBy(key string, decrement int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.DecrBy(key, decrement) })
+}
+
+// Del queues Redis.Del on the pipeline.
+func (p *Pipeline) Del(keys ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.Del(keys...) })
+}
+
+// Dump queues Redis.Dump on the pipeline.
+func (p *Pipeline) Dump(key string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.Dump(key) })
+}
+
+// Echo queues Redis.Echo on the pipeline.
+func (p *Pipeline) Echo(message string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.Echo(message) })
+}
+
+// Eval queues Redis.Eval on the pipeline.
+func (p *Pipeline) Eval(script string, keys []string, args []string) *ReplyCmd {
+	return queue(p, func(r *Redis) (*Reply, error) { return r.Eval(script, keys, args) })
+}
+
+// EvalRO queues Redis.EvalRO on the pipeline.
+func (p *Pipeline) EvalRO(script string, keys []string, args []string) *ReplyCmd {
+	return queue(p, func(r *Redis) (*Reply, error) { return r.EvalRO(script, keys, args) })
+}
+
+// EvalSha queues Redis.EvalSha on the pipeline.
+func (p *Pipeline) EvalSha(sha1 string, keys []string, args []string) *ReplyCmd {
+	return queue(p, func(r *Redis) (*Reply, error) { return r.EvalSha(sha1, keys, args) })
+}
+
+// EvalShaRO queues Redis.EvalShaRO on the pipeline.
+func (p *Pipeline) EvalShaRO(sha1 string, keys []string, args []string) *ReplyCmd {
+	return queue(p, func(r *Redis) (*Reply, error) { return r.EvalShaRO(sha1, keys, args) })
+}
+
+// Exists queues Redis.Exists on the pipeline.
+func (p *Pipeline) Exists(key string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.Exists(key) })
+}
+
+// Expire queues Redis.Expire on the pipeline.
+func (p *Pipeline) Expire(key string, seconds int) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.Expire(key, seconds) })
+}
+
+// ExpireAt queues Redis.ExpireAt on the pipeline.
+func (p *Pipeline) ExpireAt(key string, timestamp int64) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.ExpireAt(key, timestamp) })
+}
+
+// ExpireAtWithOptions queues Redis.ExpireAtWithOptions on the pipeline.
+func (p *Pipeline) ExpireAtWithOptions(key string, at time.Time, cond ExpireCondition) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.ExpireAtWithOptions(key, at, cond) })
+}
+
+// ExpireTime queues Redis.ExpireTime on the pipeline.
+func (p *Pipeline) ExpireTime(key string) *Cmd[time.Time] {
+	return queue(p, func(r *Redis) (time.Time, error) { return r.ExpireTime(key) })
+}
+
+// ExpireWithOptions queues Redis.ExpireWithOptions on the pipeline.
+func (p *Pipeline) ExpireWithOptions(key string, ttl time.Duration, cond ExpireCondition) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.ExpireWithOptions(key, ttl, cond) })
+}
+
+// FCall queues Redis.FCall on the pipeline.
+func (p *Pipeline) FCall(function string, keys []string, args ...interface{}) *ReplyCmd {
+	return queue(p, func(r *Redis) (*Reply, error) { return r.FCall(function, keys, args...) })
+}
+
+// FCallRO queues Redis.FCallRO on the pipeline.
+func (p *Pipeline) FCallRO(function string, keys []string, args ...interface{}) *ReplyCmd {
+	return queue(p, func(r *Redis) (*Reply, error) { return r.FCallRO(function, keys, args...) })
+}
+
+// FTAdd queues Redis.FTAdd on the pipeline.
+func (p *Pipeline) FTAdd(index string, docID string, score float64, fields map[string]interface{}, options ...string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.FTAdd(index, docID, score, fields, options...) })
+}
+
+// FTAggregate queues Redis.FTAggregate on the pipeline.
+func (p *Pipeline) FTAggregate(index string, query string, options ...*FTAggregateOptions) *Cmd[[]interface{}] {
+	return queue(p, func(r *Redis) ([]interface{}, error) { return r.FTAggregate(index, query, options...) })
+}
+
+// FTCreate queues Redis.FTCreate on the pipeline.
+func (p *Pipeline) FTCreate(index string, schema []FTFieldSchema, options ...*FTCreateOptions) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.FTCreate(index, schema, options...) })
+}
+
+// FTDel queues Redis.FTDel on the pipeline.
+func (p *Pipeline) FTDel(index string, docID string, deleteDocument ...bool) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.FTDel(index, docID, deleteDocument...) })
+}
+
+// FTDropIndex queues Redis.FTDropIndex on the pipeline.
+func (p *Pipeline) FTDropIndex(index string, deleteDocuments ...bool) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.FTDropIndex(index, deleteDocuments...) })
+}
+
+// FTExplain queues Redis.FTExplain on the pipeline.
+func (p *Pipeline) FTExplain(index string, query string, dialect ...int) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.FTExplain(index, query, dialect...) })
+}
+
+// FTInfo queues Redis.FTInfo on the pipeline.
+func (p *Pipeline) FTInfo(index string) *InterfaceMapCmd {
+	return queue(p, func(r *Redis) (map[string]interface{}, error) { return r.FTInfo(index) })
+}
+
+// FTSearch queues Redis.FTSearch on the pipeline.
+func (p *Pipeline) FTSearch(index string, query string, options ...*FTSearchOptions) *Cmd[[]interface{}] {
+	return queue(p, func(r *Redis) ([]interface{}, error) { return r.FTSearch(index, query, options...) })
+}
+
+// FlushAll queues Redis.FlushAll on the pipeline.
+func (p *Pipeline) FlushAll() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.FlushAll() })
+}
+
+// FlushDB queues Redis.FlushDB on the pipeline.
+func (p *Pipeline) FlushDB() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.FlushDB() })
+}
+
+// FunctionDelete queues Redis.FunctionDelete on the pipeline.
+func (p *Pipeline) FunctionDelete(library string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.FunctionDelete(library) })
+}
+
+// FunctionDump queues Redis.FunctionDump on the pipeline.
+func (p *Pipeline) FunctionDump() *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.FunctionDump() })
+}
+
+// FunctionFlush queues Redis.FunctionFlush on the pipeline.
+func (p *Pipeline) FunctionFlush() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.FunctionFlush() })
+}
+
+// FunctionFlushAsync queues Redis.FunctionFlushAsync on the pipeline.
+func (p *Pipeline) FunctionFlushAsync() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.FunctionFlushAsync() })
+}
+
+// FunctionKill queues Redis.FunctionKill on the pipeline.
+func (p *Pipeline) FunctionKill() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.FunctionKill() })
+}
+
+// FunctionList queues Redis.FunctionList on the pipeline.
+func (p *Pipeline) FunctionList(pattern string, withCode bool) *Cmd[[]FunctionLibrary] {
+	return queue(p, func(r *Redis) ([]FunctionLibrary, error) { return r.FunctionList(pattern, withCode) })
+}
+
+// FunctionLoad queues Redis.FunctionLoad on the pipeline.
+func (p *Pipeline) FunctionLoad(code string, replace bool) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.FunctionLoad(code, replace) })
+}
+
+// FunctionRestore queues Redis.FunctionRestore on the pipeline.
+func (p *Pipeline) FunctionRestore(payload []byte, policy string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.FunctionRestore(payload, policy) })
+}
+
+// FunctionStats queues Redis.FunctionStats on the pipeline.
+func (p *Pipeline) FunctionStats() *Cmd[FunctionStats] {
+	return queue(p, func(r *Redis) (FunctionStats, error) { return r.FunctionStats() })
+}
+
+// GeoAdd queues Redis.GeoAdd on the pipeline.
+func (p *Pipeline) GeoAdd(key string, members []GeoMember) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.GeoAdd(key, members) })
+}
+
+// GeoAddWithOptions queues Redis.GeoAddWithOptions on the pipeline.
+func (p *Pipeline) GeoAddWithOptions(key string, members []GeoMember, opts GeoAddOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.GeoAddWithOptions(key, members, opts) })
+}
+
+// GeoDistWithUnit queues Redis.GeoDistWithUnit on the pipeline.
+func (p *Pipeline) GeoDistWithUnit(key string, member1 string, member2 string, unit string) *FloatCmd {
+	return queue(p, func(r *Redis) (float64, error) { return r.GeoDistWithUnit(key, member1, member2, unit) })
+}
+
+// GeoHash queues Redis.GeoHash on the pipeline.
+func (p *Pipeline) GeoHash(key string, members ...string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.GeoHash(key, members...) })
+}
+
+// GeoPos queues Redis.GeoPos on the pipeline.
+func (p *Pipeline) GeoPos(key string, members ...string) *Cmd[[]*GeoCoordinate] {
+	return queue(p, func(r *Redis) ([]*GeoCoordinate, error) { return r.GeoPos(key, members...) })
+}
+
+// GeoRadius queues Redis.GeoRadius on the pipeline.
+func (p *Pipeline) GeoRadius(key string, longitude float64, latitude float64, radius float64, unit string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.GeoRadius(key, longitude, latitude, radius, unit) })
+}
+
+// GeoRadiusByMember queues Redis.GeoRadiusByMember on the pipeline.
+func (p *Pipeline) GeoRadiusByMember(key string, member string, radius float64, unit string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.GeoRadiusByMember(key, member, radius, unit) })
+}
+
+// GeoRadiusByMemberWithOptions queues Redis.GeoRadiusByMemberWithOptions on the pipeline.
+func (p *Pipeline) GeoRadiusByMemberWithOptions(key string, member string, radius float64, unit string, opts GeoRadiusOptions) *Cmd[[]GeoLocation] {
+	return queue(p, func(r *Redis) ([]GeoLocation, error) {
+		return r.GeoRadiusByMemberWithOptions(key, member, radius, unit, opts)
+	})
+}
+
+// GeoRadiusWithOptions queues Redis.GeoRadiusWithOptions on the pipeline.
+func (p *Pipeline) GeoRadiusWithOptions(key string, longitude float64, latitude float64, radius float64, unit string, opts GeoRadiusOptions) *Cmd[[]GeoLocation] {
+	return queue(p, func(r *Redis) ([]GeoLocation, error) {
+		return r.GeoRadiusWithOptions(key, longitude, latitude, radius, unit, opts)
+	})
+}
+
+// GeoSearch queues Redis.GeoSearch on the pipeline.
+func (p *Pipeline) GeoSearch(key string, opts GeoSearchOptions) *Cmd[[]GeoLocation] {
+	return queue(p, func(r *Redis) ([]GeoLocation, error) { return r.GeoSearch(key, opts) })
+}
+
+// GeoSearchStore queues Redis.GeoSearchStore on the pipeline.
+func (p *Pipeline) GeoSearchStore(destination string, source string, opts GeoSearchStoreOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.GeoSearchStore(destination, source, opts) })
+}
+
+// Get queues Redis.Get on the pipeline.
+func (p *Pipeline) Get(key string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.Get(key) })
+}
+
+// GetBit queues Redis.GetBit on the pipeline.
+func (p *Pipeline) GetBit(key string, offset int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.GetBit(key, offset) })
+}
+
+// GetDel queues Redis.GetDel on the pipeline.
+func (p *Pipeline) GetDel(key string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.GetDel(key) })
+}
+
+// GetEx queues Redis.GetEx on the pipeline.
+func (p *Pipeline) GetEx(key string, opts GetExOptions) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.GetEx(key, opts) })
+}
+
+// GetInt queues Redis.GetInt on the pipeline.
+func (p *Pipeline) GetInt(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.GetInt(key) })
+}
+
+// GetRange queues Redis.GetRange on the pipeline.
+func (p *Pipeline) GetRange(key string, start int, end int) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.GetRange(key, start, end) })
+}
+
+// GetSet queues Redis.GetSet on the pipeline.
+func (p *Pipeline) GetSet(key string, value string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.GetSet(key, value) })
+}
+
+// HDel queues Redis.HDel on the pipeline.
+func (p *Pipeline) HDel(key string, fields ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.HDel(key, fields...) })
+}
+
+// HExists queues Redis.HExists on the pipeline.
+func (p *Pipeline) HExists(key string, field string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.HExists(key, field) })
+}
+
+// HExpire queues Redis.HExpire on the pipeline.
+func (p *Pipeline) HExpire(key string, ttl time.Duration, cond ExpireCondition, fields ...string) *Cmd[[]HExpireResult] {
+	return queue(p, func(r *Redis) ([]HExpireResult, error) { return r.HExpire(key, ttl, cond, fields...) })
+}
+
+// HExpireAt queues Redis.HExpireAt on the pipeline.
+func (p *Pipeline) HExpireAt(key string, at time.Time, cond ExpireCondition, fields ...string) *Cmd[[]HExpireResult] {
+	return queue(p, func(r *Redis) ([]HExpireResult, error) { return r.HExpireAt(key, at, cond, fields...) })
+}
+
+// HExpireTime queues Redis.HExpireTime on the pipeline.
+func (p *Pipeline) HExpireTime(key string, fields ...string) *Cmd[[]HFieldExpireTime] {
+	return queue(p, func(r *Redis) ([]HFieldExpireTime, error) { return r.HExpireTime(key, fields...) })
+}
+
+// HGet queues Redis.HGet on the pipeline.
+func (p *Pipeline) HGet(key string, field string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.HGet(key, field) })
+}
+
+// HGetAll queues Redis.HGetAll on the pipeline.
+func (p *Pipeline) HGetAll(key string) *StringMapCmd {
+	return queue(p, func(r *Redis) (map[string]string, error) { return r.HGetAll(key) })
+}
+
+// HGetAllInto queues Redis.HGetAllInto on the pipeline.
+func (p *Pipeline) HGetAllInto(key string, dest interface{}) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.HGetAllInto(key, dest) })
+}
+
+// HGetDel queues Redis.HGetDel on the pipeline.
+func (p *Pipeline) HGetDel(key string, fields ...string) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.HGetDel(key, fields...) })
+}
+
+// HGetEx queues Redis.HGetEx on the pipeline.
+func (p *Pipeline) HGetEx(key string, opts GetExOptions, fields ...string) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.HGetEx(key, opts, fields...) })
+}
+
+// HIncrBy queues Redis.HIncrBy on the pipeline.
+func (p *Pipeline) HIncrBy(key string, field string, increment int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.HIncrBy(key, field, increment) })
+}
+
+// HIncrByFloat queues Redis.HIncrByFloat on the pipeline.
+func (p *Pipeline) HIncrByFloat(key string, field string, increment float64) *FloatCmd {
+	return queue(p, func(r *Redis) (float64, error) { return r.HIncrByFloat(key, field, increment) })
+}
+
+// HKeys queues Redis.HKeys on the pipeline.
+func (p *Pipeline) HKeys(key string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.HKeys(key) })
+}
+
+// HLen queues Redis.HLen on the pipeline.
+func (p *Pipeline) HLen(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.HLen(key) })
+}
+
+// HMGet queues Redis.HMGet on the pipeline.
+func (p *Pipeline) HMGet(key string, fields ...string) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.HMGet(key, fields...) })
+}
+
+// HMSet queues Redis.HMSet on the pipeline.
+func (p *Pipeline) HMSet(key string, pairs map[string]string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.HMSet(key, pairs) })
+}
+
+// HPExpire queues Redis.HPExpire on the pipeline.
+func (p *Pipeline) HPExpire(key string, ttl time.Duration, cond ExpireCondition, fields ...string) *Cmd[[]HExpireResult] {
+	return queue(p, func(r *Redis) ([]HExpireResult, error) { return r.HPExpire(key, ttl, cond, fields...) })
+}
+
+// HPExpireAt queues Redis.HPExpireAt on the pipeline.
+func (p *Pipeline) HPExpireAt(key string, at time.Time, cond ExpireCondition, fields ...string) *Cmd[[]HExpireResult] {
+	return queue(p, func(r *Redis) ([]HExpireResult, error) { return r.HPExpireAt(key, at, cond, fields...) })
+}
+
+// HPExpireTime queues Redis.HPExpireTime on the pipeline.
+func (p *Pipeline) HPExpireTime(key string, fields ...string) *Cmd[[]HFieldExpireTime] {
+	return queue(p, func(r *Redis) ([]HFieldExpireTime, error) { return r.HPExpireTime(key, fields...) })
+}
+
+// HPTTL queues Redis.HPTTL on the pipeline.
+func (p *Pipeline) HPTTL(key string, fields ...string) *Cmd[[]HFieldTTL] {
+	return queue(p, func(r *Redis) ([]HFieldTTL, error) { return r.HPTTL(key, fields...) })
+}
+
+// HPersist queues Redis.HPersist on the pipeline.
+func (p *Pipeline) HPersist(key string, fields ...string) *Cmd[[]HPersistResult] {
+	return queue(p, func(r *Redis) ([]HPersistResult, error) { return r.HPersist(key, fields...) })
+}
+
+// HRandField queues Redis.HRandField on the pipeline.
+func (p *Pipeline) HRandField(key string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.HRandField(key) })
+}
+
+// HRandFieldWithOptions queues Redis.HRandFieldWithOptions on the pipeline.
+func (p *Pipeline) HRandFieldWithOptions(key string, opts HRandFieldOptions) *Cmd[[]HField] {
+	return queue(p, func(r *Redis) ([]HField, error) { return r.HRandFieldWithOptions(key, opts) })
+}
+
+// HSet queues Redis.HSet on the pipeline.
+func (p *Pipeline) HSet(key string, field string, value string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.HSet(key, field, value) })
+}
+
+// HSetEx queues Redis.HSetEx on the pipeline.
+func (p *Pipeline) HSetEx(key string, pairs map[string]string, opts HSetExOptions) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.HSetEx(key, pairs, opts) })
+}
+
+// HSetStruct queues Redis.HSetStruct on the pipeline.
+func (p *Pipeline) HSetStruct(key string, v interface{}) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.HSetStruct(key, v) })
+}
+
+// HSetnx queues Redis.HSetnx on the pipeline.
+func (p *Pipeline) HSetnx(key string, field string, value string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.HSetnx(key, field, value) })
+}
+
+// HStrLen queues Redis.HStrLen on the pipeline.
+func (p *Pipeline) HStrLen(key string, field string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.HStrLen(key, field) })
+}
+
+// HTTL queues Redis.HTTL on the pipeline.
+func (p *Pipeline) HTTL(key string, fields ...string) *Cmd[[]HFieldTTL] {
+	return queue(p, func(r *Redis) ([]HFieldTTL, error) { return r.HTTL(key, fields...) })
+}
+
+// HVals queues Redis.HVals on the pipeline.
+func (p *Pipeline) HVals(key string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.HVals(key) })
+}
+
+// Hello queues Redis.Hello on the pipeline.
+func (p *Pipeline) Hello(protocolVersion int) *InterfaceMapCmd {
+	return queue(p, func(r *Redis) (map[string]interface{}, error) { return r.Hello(protocolVersion) })
+}
+
+// HelloWithOptions queues Redis.HelloWithOptions on the pipeline.
+func (p *Pipeline) HelloWithOptions(opts HelloOptions) *InterfaceMapCmd {
+	return queue(p, func(r *Redis) (map[string]interface{}, error) { return r.HelloWithOptions(opts) })
+}
+
+// Incr queues Redis.Incr on the pipeline.
+func (p *Pipeline) Incr(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.Incr(key) })
+}
+
+// IncrBy queues Redis.IncrBy on the pipeline.
+func (p *Pipeline) IncrBy(key string, increment int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.IncrBy(key, increment) })
+}
+
+// IncrByFloat queues Redis.IncrByFloat on the pipeline.
+func (p *Pipeline) IncrByFloat(key string, increment float64) *FloatCmd {
+	return queue(p, func(r *Redis) (float64, error) { return r.IncrByFloat(key, increment) })
+}
+
+// Info queues Redis.Info on the pipeline.
+func (p *Pipeline) Info() *Cmd[structures.RedisInfoAll] {
+	return queue(p, func(r *Redis) (structures.RedisInfoAll, error) { return r.Info() })
+}
+
+// InfoString queues Redis.InfoString on the pipeline.
+func (p *Pipeline) InfoString(section string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.InfoString(section) })
+}
+
+// JSONArrAppend queues Redis.JSONArrAppend on the pipeline.
+func (p *Pipeline) JSONArrAppend(key string, path string, values ...interface{}) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.JSONArrAppend(key, path, values...) })
+}
+
+// JSONArrIndex queues Redis.JSONArrIndex on the pipeline.
+func (p *Pipeline) JSONArrIndex(key string, path string, value interface{}, startStop ...int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.JSONArrIndex(key, path, value, startStop...) })
+}
+
+// JSONArrInsert queues Redis.JSONArrInsert on the pipeline.
+func (p *Pipeline) JSONArrInsert(key string, path string, index int, values ...interface{}) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.JSONArrInsert(key, path, index, values...) })
+}
+
+// JSONArrLen queues Redis.JSONArrLen on the pipeline.
+func (p *Pipeline) JSONArrLen(key string, path ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.JSONArrLen(key, path...) })
+}
+
+// JSONArrPop queues Redis.JSONArrPop on the pipeline.
+func (p *Pipeline) JSONArrPop(key string, path string, index ...int) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.JSONArrPop(key, path, index...) })
+}
+
+// JSONArrTrim queues Redis.JSONArrTrim on the pipeline.
+func (p *Pipeline) JSONArrTrim(key string, path string, start int, stop int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.JSONArrTrim(key, path, start, stop) })
+}
+
+// JSONDel queues Redis.JSONDel on the pipeline.
+func (p *Pipeline) JSONDel(key string, path ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.JSONDel(key, path...) })
+}
+
+// JSONGet queues Redis.JSONGet on the pipeline.
+func (p *Pipeline) JSONGet(key string, options ...*JSONGetOptions) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.JSONGet(key, options...) })
+}
+
+// JSONNumIncrBy queues Redis.JSONNumIncrBy on the pipeline.
+func (p *Pipeline) JSONNumIncrBy(key string, path string, number float64) *FloatCmd {
+	return queue(p, func(r *Redis) (float64, error) { return r.JSONNumIncrBy(key, path, number) })
+}
+
+// JSONNumMultBy queues Redis.JSONNumMultBy on the pipeline.
+func (p *Pipeline) JSONNumMultBy(key string, path string, number float64) *FloatCmd {
+	return queue(p, func(r *Redis) (float64, error) { return r.JSONNumMultBy(key, path, number) })
+}
+
+// JSONObjKeys queues Redis.JSONObjKeys on the pipeline.
+func (p *Pipeline) JSONObjKeys(key string, path ...string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.JSONObjKeys(key, path...) })
+}
+
+// JSONObjLen queues Redis.JSONObjLen on the pipeline.
+func (p *Pipeline) JSONObjLen(key string, path ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.JSONObjLen(key, path...) })
+}
+
+// JSONSet queues Redis.JSONSet on the pipeline.
+func (p *Pipeline) JSONSet(key string, path string, value interface{}, options ...*JSONSetOptions) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.JSONSet(key, path, value, options...) })
+}
+
+// JSONStrAppend queues Redis.JSONStrAppend on the pipeline.
+func (p *Pipeline) JSONStrAppend(key string, path string, jsonString string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.JSONStrAppend(key, path, jsonString) })
+}
+
+// JSONStrLen queues Redis.JSONStrLen on the pipeline.
+func (p *Pipeline) JSONStrLen(key string, path ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.JSONStrLen(key, path...) })
+}
+
+// JSONType queues Redis.JSONType on the pipeline.
+func (p *Pipeline) JSONType(key string, path ...string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.JSONType(key, path...) })
+}
+
+// Keys queues Redis.Keys on the pipeline.
+func (p *Pipeline) Keys(pattern string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.Keys(pattern) })
+}
+
+// LCS queues Redis.LCS on the pipeline.
+func (p *Pipeline) LCS(key1 string, key2 string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.LCS(key1, key2) })
+}
+
+// LCSIdx queues Redis.LCSIdx on the pipeline.
+func (p *Pipeline) LCSIdx(key1 string, key2 string, opts LCSOptions) *Cmd[LCSResult] {
+	return queue(p, func(r *Redis) (LCSResult, error) { return r.LCSIdx(key1, key2, opts) })
+}
+
+// LCSLen queues Redis.LCSLen on the pipeline.
+func (p *Pipeline) LCSLen(key1 string, key2 string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.LCSLen(key1, key2) })
+}
+
+// LIndex queues Redis.LIndex on the pipeline.
+func (p *Pipeline) LIndex(key string, index int) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.LIndex(key, index) })
+}
+
+// LInsert queues Redis.LInsert on the pipeline.
+func (p *Pipeline) LInsert(key string, position string, pivot string, value string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.LInsert(key, position, pivot, value) })
+}
+
+// LLen queues Redis.LLen on the pipeline.
+func (p *Pipeline) LLen(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.LLen(key) })
+}
+
+// LMPop queues Redis.LMPop on the pipeline.
+func (p *Pipeline) LMPop(keys []string, direction string) *Cmd[map[string][]string] {
+	return queue(p, func(r *Redis) (map[string][]string, error) { return r.LMPop(keys, direction) })
+}
+
+// LMPopWithCount queues Redis.LMPopWithCount on the pipeline.
+func (p *Pipeline) LMPopWithCount(keys []string, direction string, count int) *Cmd[map[string][]string] {
+	return queue(p, func(r *Redis) (map[string][]string, error) { return r.LMPopWithCount(keys, direction, count) })
+}
+
+// LMove queues Redis.LMove on the pipeline.
+func (p *Pipeline) LMove(source string, destination string, wherefrom string, whereto string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.LMove(source, destination, wherefrom, whereto) })
+}
+
+// LPop queues Redis.LPop on the pipeline.
+func (p *Pipeline) LPop(key string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.LPop(key) })
+}
+
+// LPos queues Redis.LPos on the pipeline.
+func (p *Pipeline) LPos(key string, element string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.LPos(key, element) })
+}
+
+// LPosWithOptions queues Redis.LPosWithOptions on the pipeline.
+func (p *Pipeline) LPosWithOptions(key string, element string, opts LPosOptions) *IntSliceCmd {
+	return queue(p, func(r *Redis) ([]int64, error) { return r.LPosWithOptions(key, element, opts) })
+}
+
+// LPush queues Redis.LPush on the pipeline.
+func (p *Pipeline) LPush(key string, values ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.LPush(key, values...) })
+}
+
+// LPushx queues Redis.LPushx on the pipeline.
+func (p *Pipeline) LPushx(key string, value string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.LPushx(key, value) })
+}
+
+// LRange queues Redis.LRange on the pipeline.
+func (p *Pipeline) LRange(key string, start int, end int) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.LRange(key, start, end) })
+}
+
+// LRem queues Redis.LRem on the pipeline.
+func (p *Pipeline) LRem(key string, count int, value string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.LRem(key, count, value) })
+}
+
+// LSet queues Redis.LSet on the pipeline.
+func (p *Pipeline) LSet(key string, index int, value string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.LSet(key, index, value) })
+}
+
+// LTrim queues Redis.LTrim on the pipeline.
+func (p *Pipeline) LTrim(key string, start int, stop int) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.LTrim(key, start, stop) })
+}
+
+// LastSave queues Redis.LastSave on the pipeline.
+func (p *Pipeline) LastSave() *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.LastSave() })
+}
+
+// LatencyDoctor queues Redis.LatencyDoctor on the pipeline.
+func (p *Pipeline) LatencyDoctor() *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.LatencyDoctor() })
+}
+
+// LatencyGraph queues Redis.LatencyGraph on the pipeline.
+func (p *Pipeline) LatencyGraph(event string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.LatencyGraph(event) })
+}
+
+// LatencyLatest queues Redis.LatencyLatest on the pipeline.
+func (p *Pipeline) LatencyLatest() *Cmd[[]LatencyStats] {
+	return queue(p, func(r *Redis) ([]LatencyStats, error) { return r.LatencyLatest() })
+}
+
+// LatencyReset queues Redis.LatencyReset on the pipeline.
+func (p *Pipeline) LatencyReset() *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.LatencyReset() })
+}
+
+// LatencyResetAll queues Redis.LatencyResetAll on the pipeline.
+func (p *Pipeline) LatencyResetAll() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.LatencyResetAll() })
+}
+
+// LatencyResetEvent queues Redis.LatencyResetEvent on the pipeline.
+func (p *Pipeline) LatencyResetEvent(event string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.LatencyResetEvent(event) })
+}
+
+// MGet queues Redis.MGet on the pipeline.
+func (p *Pipeline) MGet(keys ...string) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.MGet(keys...) })
+}
+
+// MSet queues Redis.MSet on the pipeline.
+func (p *Pipeline) MSet(pairs map[string]string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.MSet(pairs) })
+}
+
+// MSetBytes queues Redis.MSetBytes on the pipeline.
+func (p *Pipeline) MSetBytes(pairs map[string][]byte) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.MSetBytes(pairs) })
+}
+
+// MSetnx queues Redis.MSetnx on the pipeline.
+func (p *Pipeline) MSetnx(pairs map[string]string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.MSetnx(pairs) })
+}
+
+// MemoryDoctor queues Redis.MemoryDoctor on the pipeline.
+func (p *Pipeline) MemoryDoctor() *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.MemoryDoctor() })
+}
+
+// MemoryPurge queues Redis.MemoryPurge on the pipeline.
+func (p *Pipeline) MemoryPurge() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.MemoryPurge() })
+}
+
+// MemoryStats queues Redis.MemoryStats on the pipeline.
+func (p *Pipeline) MemoryStats() *Cmd[MemoryStats] {
+	return queue(p, func(r *Redis) (MemoryStats, error) { return r.MemoryStats() })
+}
+
+// MemoryUsage queues Redis.MemoryUsage on the pipeline.
+func (p *Pipeline) MemoryUsage(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.MemoryUsage(key) })
+}
+
+// MemoryUsageWithSamples queues Redis.MemoryUsageWithSamples on the pipeline.
+func (p *Pipeline) MemoryUsageWithSamples(key string, samples int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.MemoryUsageWithSamples(key, samples) })
+}
+
+// Migrate queues Redis.Migrate on the pipeline.
+func (p *Pipeline) Migrate(host string, port int, keys []string, db int, timeout time.Duration, opts MigrateOptions) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.Migrate(host, port, keys, db, timeout, opts) })
+}
+
+// ModuleList queues Redis.ModuleList on the pipeline.
+func (p *Pipeline) ModuleList() *Cmd[[]ModuleInfo] {
+	return queue(p, func(r *Redis) ([]ModuleInfo, error) { return r.ModuleList() })
+}
+
+// Move queues Redis.Move on the pipeline.
+func (p *Pipeline) Move(key string, db int) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.Move(key, db) })
+}
+
+// Object queues Redis.Object on the pipeline.
+func (p *Pipeline) Object(subcommand string, arguments ...string) *ReplyCmd {
+	return queue(p, func(r *Redis) (*Reply, error) { return r.Object(subcommand, arguments...) })
+}
+
+// PExpire queues Redis.PExpire on the pipeline.
+func (p *Pipeline) PExpire(key string, milliseconds int) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.PExpire(key, milliseconds) })
+}
+
+// PExpireAt queues Redis.PExpireAt on the pipeline.
+func (p *Pipeline) PExpireAt(key string, timestamp int64) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.PExpireAt(key, timestamp) })
+}
+
+// PExpireAtWithOptions queues Redis.PExpireAtWithOptions on the pipeline.
+func (p *Pipeline) PExpireAtWithOptions(key string, at time.Time, cond ExpireCondition) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.PExpireAtWithOptions(key, at, cond) })
+}
+
+// PExpireTime queues Redis.PExpireTime on the pipeline.
+func (p *Pipeline) PExpireTime(key string) *Cmd[time.Time] {
+	return queue(p, func(r *Redis) (time.Time, error) { return r.PExpireTime(key) })
+}
+
+// PExpireWithOptions queues Redis.PExpireWithOptions on the pipeline.
+func (p *Pipeline) PExpireWithOptions(key string, ttl time.Duration, cond ExpireCondition) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.PExpireWithOptions(key, ttl, cond) })
+}
+
+// PFAdd queues Redis.PFAdd on the pipeline.
+func (p *Pipeline) PFAdd(key string, elements ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.PFAdd(key, elements...) })
+}
+
+// PFCount queues Redis.PFCount on the pipeline.
+func (p *Pipeline) PFCount(keys ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.PFCount(keys...) })
+}
+
+// PFMerge queues Redis.PFMerge on the pipeline.
+func (p *Pipeline) PFMerge(destkey string, sourcekeys ...string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.PFMerge(destkey, sourcekeys...) })
+}
+
+// PSetex queues Redis.PSetex on the pipeline.
+func (p *Pipeline) PSetex(key string, milliseconds int, value string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.PSetex(key, milliseconds, value) })
+}
+
+// PTTL queues Redis.PTTL on the pipeline.
+func (p *Pipeline) PTTL(key string) *Cmd[time.Duration] {
+	return queue(p, func(r *Redis) (time.Duration, error) { return r.PTTL(key) })
+}
+
+// Persist queues Redis.Persist on the pipeline.
+func (p *Pipeline) Persist(key string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.Persist(key) })
+}
+
+// Ping queues Redis.Ping on the pipeline.
+func (p *Pipeline) Ping() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.Ping() })
+}
+
+// PubSubChannels queues Redis.PubSubChannels on the pipeline.
+func (p *Pipeline) PubSubChannels() *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.PubSubChannels() })
+}
+
+// PubSubChannelsWithPattern queues Redis.PubSubChannelsWithPattern on the pipeline.
+func (p *Pipeline) PubSubChannelsWithPattern(pattern string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.PubSubChannelsWithPattern(pattern) })
+}
+
+// PubSubNumPat queues Redis.PubSubNumPat on the pipeline.
+func (p *Pipeline) PubSubNumPat() *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.PubSubNumPat() })
+}
+
+// PubSubNumSub queues Redis.PubSubNumSub on the pipeline.
+func (p *Pipeline) PubSubNumSub(channels ...string) *Cmd[[]PubSubChannelInfo] {
+	return queue(p, func(r *Redis) ([]PubSubChannelInfo, error) { return r.PubSubNumSub(channels...) })
+}
+
+// Publish queues Redis.Publish on the pipeline.
+func (p *Pipeline) Publish(channel string, message string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.Publish(channel, message) })
+}
+
+// RPop queues Redis.RPop on the pipeline.
+func (p *Pipeline) RPop(key string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.RPop(key) })
+}
+
+// RPopLPush queues Redis.RPopLPush on the pipeline.
+func (p *Pipeline) RPopLPush(source string, destination string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.RPopLPush(source, destination) })
+}
+
+// RPush queues Redis.RPush on the pipeline.
+func (p *Pipeline) RPush(key string, values ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.RPush(key, values...) })
+}
+
+// RPushx queues Redis.RPushx on the pipeline.
+func (p *Pipeline) RPushx(key string, value string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.RPushx(key, value) })
+}
+
+// RandomKey queues Redis.RandomKey on the pipeline.
+func (p *Pipeline) RandomKey() *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.RandomKey() })
+}
+
+// Rename queues Redis.Rename on the pipeline.
+func (p *Pipeline) Rename(key string, newkey string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.Rename(key, newkey) })
+}
+
+// Renamenx queues Redis.Renamenx on the pipeline.
+func (p *Pipeline) Renamenx(key string, newkey string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.Renamenx(key, newkey) })
+}
+
+// ReplicaOf queues Redis.ReplicaOf on the pipeline.
+func (p *Pipeline) ReplicaOf(host string, port string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ReplicaOf(host, port) })
+}
+
+// ReplicaOfNoOne queues Redis.ReplicaOfNoOne on the pipeline.
+func (p *Pipeline) ReplicaOfNoOne() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ReplicaOfNoOne() })
+}
+
+// Reset queues Redis.Reset on the pipeline.
+func (p *Pipeline) Reset() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.Reset() })
+}
+
+// Restore queues Redis.Restore on the pipeline.
+func (p *Pipeline) Restore(key string, ttl int, serialized string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.Restore(key, ttl, serialized) })
+}
+
+// Role queues Redis.Role on the pipeline.
+func (p *Pipeline) Role() *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.Role() })
+}
+
+// RoleName queues Redis.RoleName on the pipeline.
+func (p *Pipeline) RoleName() *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.RoleName() })
+}
+
+// SAdd queues Redis.SAdd on the pipeline.
+func (p *Pipeline) SAdd(key string, members ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SAdd(key, members...) })
+}
+
+// SAddBytes queues Redis.SAddBytes on the pipeline.
+func (p *Pipeline) SAddBytes(key string, members ...[]byte) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SAddBytes(key, members...) })
+}
+
+// SCard queues Redis.SCard on the pipeline.
+func (p *Pipeline) SCard(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SCard(key) })
+}
+
+// SDiff queues Redis.SDiff on the pipeline.
+func (p *Pipeline) SDiff(keys ...string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.SDiff(keys...) })
+}
+
+// SDiffBytes queues Redis.SDiffBytes on the pipeline.
+func (p *Pipeline) SDiffBytes(keys ...string) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.SDiffBytes(keys...) })
+}
+
+// SDiffStore queues Redis.SDiffStore on the pipeline.
+func (p *Pipeline) SDiffStore(destination string, keys ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SDiffStore(destination, keys...) })
+}
+
+// SInter queues Redis.SInter on the pipeline.
+func (p *Pipeline) SInter(keys ...string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.SInter(keys...) })
+}
+
+// SInterBytes queues Redis.SInterBytes on the pipeline.
+func (p *Pipeline) SInterBytes(keys ...string) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.SInterBytes(keys...) })
+}
+
+// SInterCard queues Redis.SInterCard on the pipeline.
+func (p *Pipeline) SInterCard(keys []string, limit int64) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SInterCard(keys, limit) })
+}
+
+// SInterStore queues Redis.SInterStore on the pipeline.
+func (p *Pipeline) SInterStore(destination string, keys ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SInterStore(destination, keys...) })
+}
+
+// SIsMember queues Redis.SIsMember on the pipeline.
+func (p *Pipeline) SIsMember(key string, member string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.SIsMember(key, member) })
+}
+
+// SIsMemberBytes queues Redis.SIsMemberBytes on the pipeline.
+func (p *Pipeline) SIsMemberBytes(key string, member []byte) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.SIsMemberBytes(key, member) })
+}
+
+// SMIsMember queues Redis.SMIsMember on the pipeline.
+func (p *Pipeline) SMIsMember(key string, members ...string) *BoolSliceCmd {
+	return queue(p, func(r *Redis) ([]bool, error) { return r.SMIsMember(key, members...) })
+}
+
+// SMIsMemberBytes queues Redis.SMIsMemberBytes on the pipeline.
+func (p *Pipeline) SMIsMemberBytes(key string, members ...[]byte) *BoolSliceCmd {
+	return queue(p, func(r *Redis) ([]bool, error) { return r.SMIsMemberBytes(key, members...) })
+}
+
+// SMembers queues Redis.SMembers on the pipeline.
+func (p *Pipeline) SMembers(key string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.SMembers(key) })
+}
+
+// SMembersBytes queues Redis.SMembersBytes on the pipeline.
+func (p *Pipeline) SMembersBytes(key string) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.SMembersBytes(key) })
+}
+
+// SMove queues Redis.SMove on the pipeline.
+func (p *Pipeline) SMove(source string, destination string, member string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.SMove(source, destination, member) })
+}
+
+// SMoveBytes queues Redis.SMoveBytes on the pipeline.
+func (p *Pipeline) SMoveBytes(source string, destination string, member []byte) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.SMoveBytes(source, destination, member) })
+}
+
+// SPop queues Redis.SPop on the pipeline.
+func (p *Pipeline) SPop(key string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.SPop(key) })
+}
+
+// SPopCount queues Redis.SPopCount on the pipeline.
+func (p *Pipeline) SPopCount(key string, count int) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.SPopCount(key, count) })
+}
+
+// SPopCountBytes queues Redis.SPopCountBytes on the pipeline.
+func (p *Pipeline) SPopCountBytes(key string, count int) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.SPopCountBytes(key, count) })
+}
+
+// SPublish queues Redis.SPublish on the pipeline.
+func (p *Pipeline) SPublish(shardchannel string, message string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SPublish(shardchannel, message) })
+}
+
+// SRandMember queues Redis.SRandMember on the pipeline.
+func (p *Pipeline) SRandMember(key string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.SRandMember(key) })
+}
+
+// SRandMemberCount queues Redis.SRandMemberCount on the pipeline.
+func (p *Pipeline) SRandMemberCount(key string, count int) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.SRandMemberCount(key, count) })
+}
+
+// SRandMemberCountBytes queues Redis.SRandMemberCountBytes on the pipeline.
+func (p *Pipeline) SRandMemberCountBytes(key string, count int) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.SRandMemberCountBytes(key, count) })
+}
+
+// SRem queues Redis.SRem on the pipeline.
+func (p *Pipeline) SRem(key string, members ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SRem(key, members...) })
+}
+
+// SRemBytes queues Redis.SRemBytes on the pipeline.
+func (p *Pipeline) SRemBytes(key string, members ...[]byte) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SRemBytes(key, members...) })
+}
+
+// SUnion queues Redis.SUnion on the pipeline.
+func (p *Pipeline) SUnion(keys ...string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.SUnion(keys...) })
+}
+
+// SUnionBytes queues Redis.SUnionBytes on the pipeline.
+func (p *Pipeline) SUnionBytes(keys ...string) *BytesSliceCmd {
+	return queue(p, func(r *Redis) ([][]byte, error) { return r.SUnionBytes(keys...) })
+}
+
+// SUnionStore queues Redis.SUnionStore on the pipeline.
+func (p *Pipeline) SUnionStore(destination string, keys ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SUnionStore(destination, keys...) })
+}
+
+// Save queues Redis.Save on the pipeline.
+func (p *Pipeline) Save() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.Save() })
+}
+
+// ScriptExists queues Redis.ScriptExists on the pipeline.
+func (p *Pipeline) ScriptExists(scripts ...string) *BoolSliceCmd {
+	return queue(p, func(r *Redis) ([]bool, error) { return r.ScriptExists(scripts...) })
+}
+
+// ScriptFlush queues Redis.ScriptFlush on the pipeline.
+func (p *Pipeline) ScriptFlush() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ScriptFlush() })
+}
+
+// ScriptKill queues Redis.ScriptKill on the pipeline.
+func (p *Pipeline) ScriptKill() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.ScriptKill() })
+}
+
+// ScriptLoad queues Redis.ScriptLoad on the pipeline.
+func (p *Pipeline) ScriptLoad(script string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.ScriptLoad(script) })
+}
+
+// SentinelInfo queues Redis.SentinelInfo on the pipeline.
+func (p *Pipeline) SentinelInfo() *Cmd[structures.RedisInfoAll] {
+	return queue(p, func(r *Redis) (structures.RedisInfoAll, error) { return r.SentinelInfo() })
+}
+
+// SentinelMonitor queues Redis.SentinelMonitor on the pipeline.
+func (p *Pipeline) SentinelMonitor(podname string, ip string, port int, quorum int) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.SentinelMonitor(podname, ip, port, quorum) })
+}
+
+// SentinelRemove queues Redis.SentinelRemove on the pipeline.
+func (p *Pipeline) SentinelRemove(podname string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.SentinelRemove(podname) })
+}
+
+// SentinelSetInt queues Redis.SentinelSetInt on the pipeline.
+func (p *Pipeline) SentinelSetInt(podname string, skey string, sval int) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.SentinelSetInt(podname, skey, sval) })
+}
+
+// SentinelSetPass queues Redis.SentinelSetPass on the pipeline.
+func (p *Pipeline) SentinelSetPass(podname string, password string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.SentinelSetPass(podname, password) })
+}
+
+// SentinelSetString queues Redis.SentinelSetString on the pipeline.
+func (p *Pipeline) SentinelSetString(podname string, skey string, sval string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.SentinelSetString(podname, skey, sval) })
+}
+
+// Set queues Redis.Set on the pipeline.
+func (p *Pipeline) Set(key string, value string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.Set(key, value) })
+}
+
+// SetBit queues Redis.SetBit on the pipeline.
+func (p *Pipeline) SetBit(key string, offset int, value int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SetBit(key, offset, value) })
+}
+
+// SetBytes queues Redis.SetBytes on the pipeline.
+func (p *Pipeline) SetBytes(key string, value []byte) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.SetBytes(key, value) })
+}
+
+// SetMX queues Redis.SetMX on the pipeline.
+func (p *Pipeline) SetMX(key string, value string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.SetMX(key, value) })
+}
+
+// SetRange queues Redis.SetRange on the pipeline.
+func (p *Pipeline) SetRange(key string, offset int, value string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SetRange(key, offset, value) })
+}
+
+// SetRangeBytes queues Redis.SetRangeBytes on the pipeline.
+func (p *Pipeline) SetRangeBytes(key string, offset int, value []byte) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SetRangeBytes(key, offset, value) })
+}
+
+// SetWithOptions queues Redis.SetWithOptions on the pipeline.
+func (p *Pipeline) SetWithOptions(key string, value interface{}, opts SetOptions) *Cmd[SetResult] {
+	return queue(p, func(r *Redis) (SetResult, error) { return r.SetWithOptions(key, value, opts) })
+}
+
+// Setex queues Redis.Setex on the pipeline.
+func (p *Pipeline) Setex(key string, seconds int, value string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.Setex(key, seconds, value) })
+}
+
+// Setnx queues Redis.Setnx on the pipeline.
+func (p *Pipeline) Setnx(key string, value string) *BoolCmd {
+	return queue(p, func(r *Redis) (bool, error) { return r.Setnx(key, value) })
+}
+
+// Shutdown queues Redis.Shutdown on the pipeline.
+func (p *Pipeline) Shutdown(save bool) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.Shutdown(save) })
+}
+
+// SlaveOf queues Redis.SlaveOf on the pipeline.
+func (p *Pipeline) SlaveOf(host string, port string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.SlaveOf(host, port) })
+}
+
+// SlowLogGet queues Redis.SlowLogGet on the pipeline.
+func (p *Pipeline) SlowLogGet(n int64) *Cmd[[]*SlowLog] {
+	return queue(p, func(r *Redis) ([]*SlowLog, error) { return r.SlowLogGet(n) })
+}
+
+// SlowLogLen queues Redis.SlowLogLen on the pipeline.
+func (p *Pipeline) SlowLogLen() *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.SlowLogLen() })
+}
+
+// SlowLogReset queues Redis.SlowLogReset on the pipeline.
+func (p *Pipeline) SlowLogReset() *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.SlowLogReset() })
+}
+
+// StrLen queues Redis.StrLen on the pipeline.
+func (p *Pipeline) StrLen(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.StrLen(key) })
+}
+
+// SwapDB queues Redis.SwapDB on the pipeline.
+func (p *Pipeline) SwapDB(index1 int, index2 int) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.SwapDB(index1, index2) })
+}
+
+// TSAdd queues Redis.TSAdd on the pipeline.
+func (p *Pipeline) TSAdd(key string, timestamp int64, value float64, options ...*TSAddOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.TSAdd(key, timestamp, value, options...) })
+}
+
+// TSCreate queues Redis.TSCreate on the pipeline.
+func (p *Pipeline) TSCreate(key string, options ...*TSCreateOptions) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.TSCreate(key, options...) })
+}
+
+// TSDecrBy queues Redis.TSDecrBy on the pipeline.
+func (p *Pipeline) TSDecrBy(key string, value float64, options ...*TSDecrByOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.TSDecrBy(key, value, options...) })
+}
+
+// TSIncrBy queues Redis.TSIncrBy on the pipeline.
+func (p *Pipeline) TSIncrBy(key string, value float64, options ...*TSIncrByOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.TSIncrBy(key, value, options...) })
+}
+
+// TSInfo queues Redis.TSInfo on the pipeline.
+func (p *Pipeline) TSInfo(key string) *Cmd[*TSInfo] {
+	return queue(p, func(r *Redis) (*TSInfo, error) { return r.TSInfo(key) })
+}
+
+// TSMAdd queues Redis.TSMAdd on the pipeline.
+func (p *Pipeline) TSMAdd(samples ...TSMAddSample) *IntSliceCmd {
+	return queue(p, func(r *Redis) ([]int64, error) { return r.TSMAdd(samples...) })
+}
+
+// TSMRange queues Redis.TSMRange on the pipeline.
+func (p *Pipeline) TSMRange(fromTimestamp int64, toTimestamp int64, filters []string, options ...*TSMRangeOptions) *Cmd[map[string][]TSSample] {
+	return queue(p, func(r *Redis) (map[string][]TSSample, error) {
+		return r.TSMRange(fromTimestamp, toTimestamp, filters, options...)
+	})
+}
+
+// TSMRevRange queues Redis.TSMRevRange on the pipeline.
+func (p *Pipeline) TSMRevRange(fromTimestamp int64, toTimestamp int64, filters []string, options ...*TSMRangeOptions) *Cmd[map[string][]TSSample] {
+	return queue(p, func(r *Redis) (map[string][]TSSample, error) {
+		return r.TSMRevRange(fromTimestamp, toTimestamp, filters, options...)
+	})
+}
+
+// TSRange queues Redis.TSRange on the pipeline.
+func (p *Pipeline) TSRange(key string, fromTimestamp int64, toTimestamp int64, options ...*TSRangeOptions) *Cmd[[]TSSample] {
+	return queue(p, func(r *Redis) ([]TSSample, error) { return r.TSRange(key, fromTimestamp, toTimestamp, options...) })
+}
+
+// TSRevRange queues Redis.TSRevRange on the pipeline.
+func (p *Pipeline) TSRevRange(key string, fromTimestamp int64, toTimestamp int64, options ...*TSRangeOptions) *Cmd[[]TSSample] {
+	return queue(p, func(r *Redis) ([]TSSample, error) { return r.TSRevRange(key, fromTimestamp, toTimestamp, options...) })
+}
+
+// TTL queues Redis.TTL on the pipeline.
+func (p *Pipeline) TTL(key string) *Cmd[time.Duration] {
+	return queue(p, func(r *Redis) (time.Duration, error) { return r.TTL(key) })
+}
+
+// Time queues Redis.Time on the pipeline.
+func (p *Pipeline) Time() *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.Time() })
+}
+
+// Touch queues Redis.Touch on the pipeline.
+func (p *Pipeline) Touch(keys ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.Touch(keys...) })
+}
+
+// Type queues Redis.Type on the pipeline.
+func (p *Pipeline) Type(key string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.Type(key) })
+}
+
+// Unlink queues Redis.Unlink on the pipeline.
+func (p *Pipeline) Unlink(keys ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.Unlink(keys...) })
+}
+
+// Wait queues Redis.Wait on the pipeline.
+func (p *Pipeline) Wait(numreplicas int, timeout int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.Wait(numreplicas, timeout) })
+}
+
+// XAck queues Redis.XAck on the pipeline.
+func (p *Pipeline) XAck(key string, group string, ids ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.XAck(key, group, ids...) })
+}
+
+// XAdd queues Redis.XAdd on the pipeline.
+func (p *Pipeline) XAdd(key string, id string, fields map[string]string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.XAdd(key, id, fields) })
+}
+
+// XAddWithOptions queues Redis.XAddWithOptions on the pipeline.
+func (p *Pipeline) XAddWithOptions(key string, id string, fields map[string]string, opts XAddOptions) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.XAddWithOptions(key, id, fields, opts) })
+}
+
+// XClaim queues Redis.XClaim on the pipeline.
+func (p *Pipeline) XClaim(key string, group string, consumer string, minIdleTime int64, ids []string) *StreamEntriesCmd {
+	return queue(p, func(r *Redis) ([]StreamEntry, error) { return r.XClaim(key, group, consumer, minIdleTime, ids) })
+}
+
+// XClaimWithOptions queues Redis.XClaimWithOptions on the pipeline.
+func (p *Pipeline) XClaimWithOptions(key string, group string, consumer string, minIdleTime int64, ids []string, opts XClaimOptions) *StreamEntriesCmd {
+	return queue(p, func(r *Redis) ([]StreamEntry, error) {
+		return r.XClaimWithOptions(key, group, consumer, minIdleTime, ids, opts)
+	})
+}
+
+// XDel queues Redis.XDel on the pipeline.
+func (p *Pipeline) XDel(key string, ids ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.XDel(key, ids...) })
+}
+
+// XGroupCreate queues Redis.XGroupCreate on the pipeline.
+func (p *Pipeline) XGroupCreate(key string, groupname string, id string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.XGroupCreate(key, groupname, id) })
+}
+
+// XGroupCreateWithOptions queues Redis.XGroupCreateWithOptions on the pipeline.
+func (p *Pipeline) XGroupCreateWithOptions(key string, groupname string, id string, opts XGroupCreateOptions) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) {
+		return struct{}{}, r.XGroupCreateWithOptions(key, groupname, id, opts)
+	})
+}
+
+// XGroupDestroy queues Redis.XGroupDestroy on the pipeline.
+func (p *Pipeline) XGroupDestroy(key string, groupname string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.XGroupDestroy(key, groupname) })
+}
+
+// XGroupSetID queues Redis.XGroupSetID on the pipeline.
+func (p *Pipeline) XGroupSetID(key string, groupname string, id string) *StatusCmd {
+	return queue(p, func(r *Redis) (struct{}, error) { return struct{}{}, r.XGroupSetID(key, groupname, id) })
+}
+
+// XInfoConsumers queues Redis.XInfoConsumers on the pipeline.
+func (p *Pipeline) XInfoConsumers(key string, groupname string) *Cmd[[]StreamConsumerInfo] {
+	return queue(p, func(r *Redis) ([]StreamConsumerInfo, error) { return r.XInfoConsumers(key, groupname) })
+}
+
+// XInfoGroups queues Redis.XInfoGroups on the pipeline.
+func (p *Pipeline) XInfoGroups(key string) *Cmd[[]StreamGroupInfo] {
+	return queue(p, func(r *Redis) ([]StreamGroupInfo, error) { return r.XInfoGroups(key) })
+}
+
+// XInfoStream queues Redis.XInfoStream on the pipeline.
+func (p *Pipeline) XInfoStream(key string) *Cmd[StreamInfo] {
+	return queue(p, func(r *Redis) (StreamInfo, error) { return r.XInfoStream(key) })
+}
+
+// XInfoStreamFull queues Redis.XInfoStreamFull on the pipeline.
+func (p *Pipeline) XInfoStreamFull(key string, count int64) *Cmd[StreamInfoFull] {
+	return queue(p, func(r *Redis) (StreamInfoFull, error) { return r.XInfoStreamFull(key, count) })
+}
+
+// XLen queues Redis.XLen on the pipeline.
+func (p *Pipeline) XLen(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.XLen(key) })
+}
+
+// XPending queues Redis.XPending on the pipeline.
+func (p *Pipeline) XPending(key string, group string) *Cmd[XPendingInfo] {
+	return queue(p, func(r *Redis) (XPendingInfo, error) { return r.XPending(key, group) })
+}
+
+// XPendingWithOptions queues Redis.XPendingWithOptions on the pipeline.
+func (p *Pipeline) XPendingWithOptions(key string, group string, opts XPendingOptions) *Cmd[[]XPendingMessage] {
+	return queue(p, func(r *Redis) ([]XPendingMessage, error) { return r.XPendingWithOptions(key, group, opts) })
+}
+
+// XRange queues Redis.XRange on the pipeline.
+func (p *Pipeline) XRange(key string, start string, end string) *StreamEntriesCmd {
+	return queue(p, func(r *Redis) ([]StreamEntry, error) { return r.XRange(key, start, end) })
+}
+
+// XRangeWithOptions queues Redis.XRangeWithOptions on the pipeline.
+func (p *Pipeline) XRangeWithOptions(key string, start string, end string, opts XRangeOptions) *StreamEntriesCmd {
+	return queue(p, func(r *Redis) ([]StreamEntry, error) { return r.XRangeWithOptions(key, start, end, opts) })
+}
+
+// XRead queues Redis.XRead on the pipeline.
+func (p *Pipeline) XRead(streams map[string]string) *Cmd[[]StreamMessage] {
+	return queue(p, func(r *Redis) ([]StreamMessage, error) { return r.XRead(streams) })
+}
+
+// XReadGroup queues Redis.XReadGroup on the pipeline.
+func (p *Pipeline) XReadGroup(group string, consumer string, streams map[string]string) *Cmd[[]StreamMessage] {
+	return queue(p, func(r *Redis) ([]StreamMessage, error) { return r.XReadGroup(group, consumer, streams) })
+}
+
+// XReadGroupWithOptions queues Redis.XReadGroupWithOptions on the pipeline.
+func (p *Pipeline) XReadGroupWithOptions(group string, consumer string, streams map[string]string, opts XReadGroupOptions) *Cmd[[]StreamMessage] {
+	return queue(p, func(r *Redis) ([]StreamMessage, error) {
+		return r.XReadGroupWithOptions(group, consumer, streams, opts)
+	})
+}
+
+// XReadWithOptions queues Redis.XReadWithOptions on the pipeline.
+func (p *Pipeline) XReadWithOptions(streams map[string]string, opts XReadOptions) *Cmd[[]StreamMessage] {
+	return queue(p, func(r *Redis) ([]StreamMessage, error) { return r.XReadWithOptions(streams, opts) })
+}
+
+// XRevRange queues Redis.XRevRange on the pipeline.
+func (p *Pipeline) XRevRange(key string, end string, start string) *StreamEntriesCmd {
+	return queue(p, func(r *Redis) ([]StreamEntry, error) { return r.XRevRange(key, end, start) })
+}
+
+// XRevRangeWithOptions queues Redis.XRevRangeWithOptions on the pipeline.
+func (p *Pipeline) XRevRangeWithOptions(key string, end string, start string, opts XRangeOptions) *StreamEntriesCmd {
+	return queue(p, func(r *Redis) ([]StreamEntry, error) { return r.XRevRangeWithOptions(key, end, start, opts) })
+}
+
+// XTrim queues Redis.XTrim on the pipeline.
+func (p *Pipeline) XTrim(key string, strategy string, threshold string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.XTrim(key, strategy, threshold) })
+}
+
+// XTrimWithOptions queues Redis.XTrimWithOptions on the pipeline.
+func (p *Pipeline) XTrimWithOptions(key string, strategy string, threshold string, opts XAddOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.XTrimWithOptions(key, strategy, threshold, opts) })
+}
+
+// ZAdd queues Redis.ZAdd on the pipeline.
+func (p *Pipeline) ZAdd(key string, score float64, val string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZAdd(key, score, val) })
+}
+
+// ZAddIncr queues Redis.ZAddIncr on the pipeline.
+func (p *Pipeline) ZAddIncr(key string, member string, increment float64, opts ZAddOptions) *FloatCmd {
+	return queue(p, func(r *Redis) (float64, error) { return r.ZAddIncr(key, member, increment, opts) })
+}
+
+// ZAddVariadic queues Redis.ZAddVariadic on the pipeline.
+func (p *Pipeline) ZAddVariadic(key string, pairs map[string]float64) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZAddVariadic(key, pairs) })
+}
+
+// ZAddWithOptions queues Redis.ZAddWithOptions on the pipeline.
+func (p *Pipeline) ZAddWithOptions(key string, members []ZMember, opts ZAddOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZAddWithOptions(key, members, opts) })
+}
+
+// ZCard queues Redis.ZCard on the pipeline.
+func (p *Pipeline) ZCard(key string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZCard(key) })
+}
+
+// ZCount queues Redis.ZCount on the pipeline.
+func (p *Pipeline) ZCount(key string, min string, max string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZCount(key, min, max) })
+}
+
+// ZDiff queues Redis.ZDiff on the pipeline.
+func (p *Pipeline) ZDiff(keys ...string) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ZDiff(keys...) })
+}
+
+// ZDiffStore queues Redis.ZDiffStore on the pipeline.
+func (p *Pipeline) ZDiffStore(destination string, keys ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZDiffStore(destination, keys...) })
+}
+
+// ZDiffWithScores queues Redis.ZDiffWithScores on the pipeline.
+func (p *Pipeline) ZDiffWithScores(keys ...string) *ZMemberSliceCmd {
+	return queue(p, func(r *Redis) ([]ZMember, error) { return r.ZDiffWithScores(keys...) })
+}
+
+// ZIncrBy queues Redis.ZIncrBy on the pipeline.
+func (p *Pipeline) ZIncrBy(key string, increment float64, member string) *FloatCmd {
+	return queue(p, func(r *Redis) (float64, error) { return r.ZIncrBy(key, increment, member) })
+}
+
+// ZInter queues Redis.ZInter on the pipeline.
+func (p *Pipeline) ZInter(keys []string, opts ZAggregateOptions) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ZInter(keys, opts) })
+}
+
+// ZInterCard queues Redis.ZInterCard on the pipeline.
+func (p *Pipeline) ZInterCard(keys []string, limit int64) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZInterCard(keys, limit) })
+}
+
+// ZInterStore queues Redis.ZInterStore on the pipeline.
+func (p *Pipeline) ZInterStore(destination string, keys []string, weights []int, aggregate string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZInterStore(destination, keys, weights, aggregate) })
+}
+
+// ZInterStoreWithOptions queues Redis.ZInterStoreWithOptions on the pipeline.
+func (p *Pipeline) ZInterStoreWithOptions(destination string, keys []string, opts ZAggregateOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZInterStoreWithOptions(destination, keys, opts) })
+}
+
+// ZInterWithScores queues Redis.ZInterWithScores on the pipeline.
+func (p *Pipeline) ZInterWithScores(keys []string, opts ZAggregateOptions) *ZMemberSliceCmd {
+	return queue(p, func(r *Redis) ([]ZMember, error) { return r.ZInterWithScores(keys, opts) })
+}
+
+// ZLexCount queues Redis.ZLexCount on the pipeline.
+func (p *Pipeline) ZLexCount(key string, min string, max string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZLexCount(key, min, max) })
+}
+
+// ZMPop queues Redis.ZMPop on the pipeline.
+func (p *Pipeline) ZMPop(keys []string, where string, count int) *Cmd[ZPopResult] {
+	return queue(p, func(r *Redis) (ZPopResult, error) { return r.ZMPop(keys, where, count) })
+}
+
+// ZMScore queues Redis.ZMScore on the pipeline.
+func (p *Pipeline) ZMScore(key string, members ...string) *FloatSliceCmd {
+	return queue(p, func(r *Redis) ([]float64, error) { return r.ZMScore(key, members...) })
+}
+
+// ZPopMax queues Redis.ZPopMax on the pipeline.
+func (p *Pipeline) ZPopMax(key string) *Cmd[ZMember] {
+	return queue(p, func(r *Redis) (ZMember, error) { return r.ZPopMax(key) })
+}
+
+// ZPopMaxCount queues Redis.ZPopMaxCount on the pipeline.
+func (p *Pipeline) ZPopMaxCount(key string, count int) *ZMemberSliceCmd {
+	return queue(p, func(r *Redis) ([]ZMember, error) { return r.ZPopMaxCount(key, count) })
+}
+
+// ZPopMin queues Redis.ZPopMin on the pipeline.
+func (p *Pipeline) ZPopMin(key string) *Cmd[ZMember] {
+	return queue(p, func(r *Redis) (ZMember, error) { return r.ZPopMin(key) })
+}
+
+// ZPopMinCount queues Redis.ZPopMinCount on the pipeline.
+func (p *Pipeline) ZPopMinCount(key string, count int) *ZMemberSliceCmd {
+	return queue(p, func(r *Redis) ([]ZMember, error) { return r.ZPopMinCount(key, count) })
+}
+
+// ZRandMember queues Redis.ZRandMember on the pipeline.
+func (p *Pipeline) ZRandMember(key string) *StringCmd {
+	return queue(p, func(r *Redis) (string, error) { return r.ZRandMember(key) })
+}
+
+// ZRandMemberWithOptions queues Redis.ZRandMemberWithOptions on the pipeline.
+func (p *Pipeline) ZRandMemberWithOptions(key string, opts ZRandMemberOptions) *ZMemberSliceCmd {
+	return queue(p, func(r *Redis) ([]ZMember, error) { return r.ZRandMemberWithOptions(key, opts) })
+}
+
+// ZRange queues Redis.ZRange on the pipeline.
+func (p *Pipeline) ZRange(key string, start int, stop int, withscores bool) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ZRange(key, start, stop, withscores) })
+}
+
+// ZRangeByLex queues Redis.ZRangeByLex on the pipeline.
+func (p *Pipeline) ZRangeByLex(key string, min string, max string, limit bool, offset int, count int) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ZRangeByLex(key, min, max, limit, offset, count) })
+}
+
+// ZRangeByScore queues Redis.ZRangeByScore on the pipeline.
+func (p *Pipeline) ZRangeByScore(key string, min string, max string, withscores bool, limit bool, offset int, count int) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) {
+		return r.ZRangeByScore(key, min, max, withscores, limit, offset, count)
+	})
+}
+
+// ZRangeStore queues Redis.ZRangeStore on the pipeline.
+func (p *Pipeline) ZRangeStore(destination string, source string, start string, stop string, opts ZRangeOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZRangeStore(destination, source, start, stop, opts) })
+}
+
+// ZRank queues Redis.ZRank on the pipeline.
+func (p *Pipeline) ZRank(key string, member string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZRank(key, member) })
+}
+
+// ZRem queues Redis.ZRem on the pipeline.
+func (p *Pipeline) ZRem(key string, members ...string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZRem(key, members...) })
+}
+
+// ZRemRangeByLex queues Redis.ZRemRangeByLex on the pipeline.
+func (p *Pipeline) ZRemRangeByLex(key string, min string, max string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZRemRangeByLex(key, min, max) })
+}
+
+// ZRemRangeByRank queues Redis.ZRemRangeByRank on the pipeline.
+func (p *Pipeline) ZRemRangeByRank(key string, start int, stop int) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZRemRangeByRank(key, start, stop) })
+}
+
+// ZRemRangeByScore queues Redis.ZRemRangeByScore on the pipeline.
+func (p *Pipeline) ZRemRangeByScore(key string, min string, max string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZRemRangeByScore(key, min, max) })
+}
+
+// ZRevRange queues Redis.ZRevRange on the pipeline.
+func (p *Pipeline) ZRevRange(key string, start int, stop int, withscores bool) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ZRevRange(key, start, stop, withscores) })
+}
+
+// ZRevRangeByScore queues Redis.ZRevRangeByScore on the pipeline.
+func (p *Pipeline) ZRevRangeByScore(key string, max string, min string, withscores bool, limit bool, offset int, count int) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) {
+		return r.ZRevRangeByScore(key, max, min, withscores, limit, offset, count)
+	})
+}
+
+// ZRevRank queues Redis.ZRevRank on the pipeline.
+func (p *Pipeline) ZRevRank(key string, member string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZRevRank(key, member) })
+}
+
+// ZScore queues Redis.ZScore on the pipeline.
+func (p *Pipeline) ZScore(key string, member string) *BytesCmd {
+	return queue(p, func(r *Redis) ([]byte, error) { return r.ZScore(key, member) })
+}
+
+// ZUnion queues Redis.ZUnion on the pipeline.
+func (p *Pipeline) ZUnion(keys []string, opts ZAggregateOptions) *StringSliceCmd {
+	return queue(p, func(r *Redis) ([]string, error) { return r.ZUnion(keys, opts) })
+}
+
+// ZUnionStore queues Redis.ZUnionStore on the pipeline.
+func (p *Pipeline) ZUnionStore(destination string, keys []string, weights []int, aggregate string) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZUnionStore(destination, keys, weights, aggregate) })
+}
+
+// ZUnionStoreWithOptions queues Redis.ZUnionStoreWithOptions on the pipeline.
+func (p *Pipeline) ZUnionStoreWithOptions(destination string, keys []string, opts ZAggregateOptions) *IntCmd {
+	return queue(p, func(r *Redis) (int64, error) { return r.ZUnionStoreWithOptions(destination, keys, opts) })
+}
+
+// ZUnionWithScores queues Redis.ZUnionWithScores on the pipeline.
+func (p *Pipeline) ZUnionWithScores(keys []string, opts ZAggregateOptions) *ZMemberSliceCmd {
+	return queue(p, func(r *Redis) ([]ZMember, error) { return r.ZUnionWithScores(keys, opts) })
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPipelineExec(t *testing.T) {
+	addr, commands := serveReplies(t,
+		":5\r\n",
+		"*4\r\n$4\r\nname\r\n$3\r\nbob\r\n$3\r\nage\r\n$2\r\n42\r\n",
+		"-WRONGTYPE Operation against a key holding the wrong kind of value\r\n",
+		"*1\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\nf\r\n$1\r\nv\r\n",
+		"+OK\r\n",
+	)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	pipe := rr.Pipeline()
+	incr := pipe.Incr("counter")
+	user := pipe.HGetAll("user")
+	get := pipe.Get("list")
+	entries := pipe.XRange("stream", "-", "+")
+	set := pipe.Set("key", "value")
+	empty := pipe.HSetStruct("key", struct{}{})
+	if err := incr.Err(); err == nil {
+		t.Error("result should not be set before Exec")
+	}
+	if pipe.Len() != 5 {
+		t.Errorf("expected 5 queued commands, got %d", pipe.Len())
+	}
+	if err := pipe.Exec(); err != nil {
+		t.Fatal(err)
+	}
+	if pipe.Len() != 0 {
+		t.Error("pipeline should be empty after Exec")
+	}
+	if n, err := incr.Result(); err != nil || n != 5 {
+		t.Errorf("unexpected incr %d %v", n, err)
+	}
+	if m := user.Val(); m["name"] != "bob" || m["age"] != "42" {
+		t.Errorf("unexpected hash %v", m)
+	}
+	if !errors.Is(get.Err(), ErrWrongType) {
+		t.Errorf("expected WRONGTYPE, got %v", get.Err())
+	}
+	if e := entries.Val(); len(e) != 1 || e[0].ID != "1-0" || e[0].Fields["f"] != "v" {
+		t.Errorf("unexpected entries %v", e)
+	}
+	if err := set.Err(); err != nil {
+		t.Error(err)
+	}
+	if empty.Err() == nil {
+		t.Error("invalid command should fail without being sent")
+	}
+	if cmd := <-commands; strings.Join(cmd, " ") != "INCR counter" {
+		t.Errorf("unexpected first command %v", cmd)
+	}
+}
+
+func TestPipelineTransportError(t *testing.T) {
+	// the connection is closed after the first reply
+	addr, _ := serveReplies(t, ":1\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	pipe := rr.Pipeline()
+	first := pipe.Incr("a")
+	second := pipe.Incr("b")
+	err = pipe.Exec()
+	if err == nil {
+		t.Fatal("expected transport error")
+	}
+	if n, err := first.Result(); err != nil || n != 1 {
+		t.Errorf("first command should have its reply, got %d %v", n, err)
+	}
+	if second.Err() != err {
+		t.Errorf("second command should carry the transport error, got %v", second.Err())
+	}
+}
+
+func TestPipelineNotPipelinable(t *testing.T) {
+	pipe := (&Redis{}).Pipeline()
+	cmd := queue(pipe, func(r *Redis) (int64, error) {
+		if _, err := r.ExecuteCommand("MULTI"); err != nil && err != errQueued {
+			return 0, err
+		}
+		rp, _ := r.ExecuteCommand("EXEC")
+		return rp.IntegerValue()
+	})
+	if cmd.Err() != errNotPipelinable || pipe.Len() != 0 {
+		t.Errorf("expected errNotPipelinable, got %v", cmd.Err())
+	}
+}
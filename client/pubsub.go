@@ -46,7 +46,8 @@ func (r *Redis) PubSub() (*PubSub, error) {
 
 // Close closes current pubsub command.
 func (p *PubSub) Close() error {
-	return p.conn.Conn.Close()
+	p.redis.pool.Discard(p.conn)
+	return nil
 }
 
 // Receive returns the reply of pubsub command.
@@ -274,7 +275,8 @@ func (r *Redis) ShardedPubSub() (*ShardedPubSub, error) {
 
 // Close closes the sharded pub/sub connection.
 func (sp *ShardedPubSub) Close() error {
-	return sp.conn.Conn.Close()
+	sp.redis.pool.Discard(sp.conn)
+	return nil
 }
 
 // SSUBSCRIBE shardchannel [shardchannel ...]
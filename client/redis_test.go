@@ -1,7 +1,14 @@
 package client
 
 import (
+	"bufio"
+	"container/list"
+	"context"
 	"fmt"
+	"math"
+	"net"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 )
@@ -20,7 +27,7 @@ var (
 )
 
 func init() {
-	client, err := DialWithConfig(&DialConfig{network, address, db, password, timeout, maxidle, false, false, "", "", "", "", tcpKeepAlive})
+	client, err := DialWithConfig(&DialConfig{Network: network, Address: address, Database: db, Password: password, Timeout: timeout, MaxIdle: maxidle, TCPKeepAlive: tcpKeepAlive})
 	if err != nil {
 		panic(err)
 	}
@@ -28,7 +35,7 @@ func init() {
 }
 
 func TestDial(t *testing.T) {
-	client, err := DialWithConfig(&DialConfig{network, address, db, password, timeout, maxidle, false, false, "", "", "", "", tcpKeepAlive})
+	client, err := DialWithConfig(&DialConfig{Network: network, Address: address, Database: db, Password: password, Timeout: timeout, MaxIdle: maxidle, TCPKeepAlive: tcpKeepAlive})
 	if err != nil {
 		t.Error(err)
 	} else if err := client.Ping(); err != nil {
@@ -38,7 +45,7 @@ func TestDial(t *testing.T) {
 }
 
 func TestDialTimeout(t *testing.T) {
-	client, err := DialWithConfig(&DialConfig{network, address, db, password, timeout, maxidle, false, false, "", "", "", "", tcpKeepAlive})
+	client, err := DialWithConfig(&DialConfig{Network: network, Address: address, Database: db, Password: password, Timeout: timeout, MaxIdle: maxidle, TCPKeepAlive: tcpKeepAlive})
 	if err != nil {
 		t.Error(err)
 	} else {
@@ -71,6 +78,366 @@ func TestPackCommand(t *testing.T) {
 	}
 }
 
+func recvFrom(raw string) (*Reply, error) {
+	c := &connection{Reader: bufio.NewReader(strings.NewReader(raw))}
+	return c.RecvReply()
+}
+
+func TestRecvReplyRESP3Map(t *testing.T) {
+	rp, err := recvFrom("%2\r\n+first\r\n:1\r\n$6\r\nsecond\r\n,2.5\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.Type != MapReply {
+		t.Fatalf("expected MapReply, got %d", rp.Type)
+	}
+	m, err := rp.MapValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, _ := m["first"].IntegerValue(); i != 1 {
+		t.Errorf("first = %d, should be 1", i)
+	}
+	if f, _ := m["second"].DoubleValue(); f != 2.5 {
+		t.Errorf("second = %f, should be 2.5", f)
+	}
+	if h, err := rp.HashValue(); err != nil || h["second"] != "2.5" {
+		t.Errorf("HashValue on map reply = %v, %v", h, err)
+	}
+}
+
+func TestRecvReplyRESP3Set(t *testing.T) {
+	rp, err := recvFrom("~3\r\n$1\r\na\r\n$1\r\nb\r\n:3\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	members, err := rp.SetValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(members, ",") != "a,b,3" {
+		t.Errorf("unexpected set members %v", members)
+	}
+}
+
+func TestRecvReplyRESP3Scalars(t *testing.T) {
+	rp, err := recvFrom(",-inf\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f, err := rp.DoubleValue(); err != nil || !math.IsInf(f, -1) {
+		t.Errorf("expected -inf, got %f %v", f, err)
+	}
+
+	rp, err = recvFrom("#t\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := rp.BoolValue(); err != nil || !b {
+		t.Errorf("expected true, got %v %v", b, err)
+	}
+
+	rp, err = recvFrom("(3492890328409238509324850943850943825024385\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := rp.BigNumberValue(); err != nil || n.String() != "3492890328409238509324850943850943825024385" {
+		t.Errorf("unexpected big number %v %v", n, err)
+	}
+
+	rp, err = recvFrom("_\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := rp.BytesValue(); err != nil || b != nil || !rp.IsNull() {
+		t.Errorf("expected null, got %v %v", b, err)
+	}
+
+	rp, err = recvFrom("=15\r\ntxt:Some string\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format, text, err := rp.VerbatimValue(); err != nil || format != "txt" || text != "Some string" {
+		t.Errorf("unexpected verbatim string %s %s %v", format, text, err)
+	}
+}
+
+func TestRecvReplyRESP3Errors(t *testing.T) {
+	rp, err := recvFrom("!21\r\nSYNTAX invalid syntax\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.Type != ErrorReply || rp.Error != "SYNTAX invalid syntax" {
+		t.Errorf("unexpected blob error %v", rp)
+	}
+}
+
+func TestRecvReplyRESP3Attributes(t *testing.T) {
+	rp, err := recvFrom("|1\r\n+key-popularity\r\n%1\r\n$1\r\na\r\n,0.1923\r\n*1\r\n:2039123\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.Type != MultiReply || len(rp.Multi) != 1 {
+		t.Fatalf("unexpected reply after attributes %v", rp)
+	}
+	if _, ok := rp.Attributes["key-popularity"]; !ok {
+		t.Errorf("attributes not attached to reply: %v", rp.Attributes)
+	}
+}
+
+func TestRecvReplyRESP3Push(t *testing.T) {
+	rp, err := recvFrom(">3\r\n$7\r\nmessage\r\n$4\r\nchan\r\n$5\r\nhello\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.Type != PushReply {
+		t.Fatalf("expected PushReply, got %d", rp.Type)
+	}
+	if list, err := rp.ListValue(); err != nil || len(list) != 3 {
+		t.Errorf("unexpected push contents %v %v", list, err)
+	}
+}
+
+func TestDialProtocol3(t *testing.T) {
+	client, err := DialWithConfig(&DialConfig{Network: network, Address: address, Database: db, Password: password, Timeout: timeout, MaxIdle: maxidle, Protocol: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.ClosePool()
+	if err := client.Ping(); err != nil {
+		t.Error(err)
+	}
+}
+
+func pipeDial() (*connection, error) {
+	local, remote := net.Pipe()
+	remote.Close()
+	return &connection{Conn: local, Reader: bufio.NewReader(local)}, nil
+}
+
+// serveReplies accepts one connection, answers each command on it with the
+// next of replies, and returns its address and the commands received
+func serveReplies(t *testing.T, replies ...string) (string, <-chan []string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	commands := make(chan []string, len(replies))
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		c := &connection{Conn: conn, Reader: bufio.NewReader(conn)}
+		for _, reply := range replies {
+			rp, err := c.RecvReply()
+			if err != nil {
+				return
+			}
+			args, _ := rp.ListValue()
+			commands <- args
+			if _, err := conn.Write([]byte(reply)); err != nil {
+				return
+			}
+		}
+	}()
+	return ln.Addr().String(), commands
+}
+
+func TestConnPoolReset(t *testing.T) {
+	dials := 0
+	dial := func() (*connection, error) {
+		dials++
+		return pipeDial()
+	}
+	p := &connPool{MaxIdle: 2, Dial: dial, idle: list.New()}
+	old, err := p.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.reset(dial)
+	p.Put(old)
+	if p.idle.Len() != 0 {
+		t.Error("connection from before reset should not be pooled")
+	}
+	c, err := p.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Put(c)
+	if p.idle.Len() != 1 {
+		t.Error("connection from after reset should be pooled")
+	}
+	if dials != 2 {
+		t.Errorf("expected 2 dials, got %d", dials)
+	}
+}
+
+func TestConnPoolMaxActive(t *testing.T) {
+	p := &connPool{MaxIdle: 2, MaxActive: 2, Dial: pipeDial, idle: list.New(), sem: make(chan struct{}, 2)}
+	c1, err := p.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := p.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Get(); err != ErrPoolExhausted {
+		t.Errorf("expected ErrPoolExhausted, got %v", err)
+	}
+
+	p.Wait = true
+	p.Timeout = 50 * time.Millisecond
+	if _, err := p.Get(); err != ErrPoolTimeout {
+		t.Errorf("expected ErrPoolTimeout, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p.Put(c1)
+	}()
+	p.Timeout = time.Second
+	c3, err := p.Get()
+	if err != nil {
+		t.Fatalf("waiting Get should be handed the released connection: %v", err)
+	}
+	if c3 != c1 {
+		t.Error("expected the idle connection to be reused")
+	}
+
+	stats := p.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Timeouts != 1 {
+		t.Errorf("unexpected counters %+v", stats)
+	}
+	if stats.TotalConns != 2 || stats.InUseConns != 2 || stats.IdleConns != 0 {
+		t.Errorf("unexpected connection counts %+v", stats)
+	}
+	p.Discard(c2)
+	p.Discard(c2)
+	p.Put(c3)
+	if stats := p.Stats(); stats.TotalConns != 1 || stats.IdleConns != 1 {
+		t.Errorf("unexpected connection counts after release %+v", stats)
+	}
+}
+
+func TestConnPoolIdleTimeout(t *testing.T) {
+	p := &connPool{MaxIdle: 2, IdleTimeout: 20 * time.Millisecond, Dial: pipeDial, idle: list.New()}
+	c, err := p.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Put(c)
+	time.Sleep(40 * time.Millisecond)
+	p.reap()
+	if stats := p.Stats(); stats.TotalConns != 0 || stats.IdleConns != 0 {
+		t.Errorf("expired idle connection should be reaped, got %+v", stats)
+	}
+}
+
+func TestDialFailureStopsReaper(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	before := runtime.NumGoroutine()
+	for i := 0; i < 10; i++ {
+		_, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second, IdleTimeout: time.Minute})
+		if err == nil {
+			t.Fatal("expected the dial of a closed port to fail")
+		}
+	}
+	if after := runtime.NumGoroutine(); after >= before+10 {
+		t.Errorf("failed dials leaked goroutines, %d before, %d after", before, after)
+	}
+}
+
+func TestDialHandshakeErrorClosesConn(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	closed := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			closed <- err
+			return
+		}
+		defer conn.Close()
+		c := &connection{Conn: conn, Reader: bufio.NewReader(conn)}
+		if _, err := c.RecvReply(); err != nil {
+			closed <- err
+			return
+		}
+		conn.Write([]byte("-WRONGPASS invalid username-password pair\r\n"))
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		// the client hangs up instead of leaving the socket open
+		_, err = c.RecvReply()
+		closed <- err
+	}()
+	if _, err := DialWithConfig(&DialConfig{Address: ln.Addr().String(), Timeout: time.Second, Password: "wrong", Protocol: 3}); err == nil {
+		t.Fatal("expected the HELLO error")
+	}
+	if err := <-closed; err == nil || strings.Contains(err.Error(), "timeout") {
+		t.Errorf("expected the connection to be closed, got %v", err)
+	}
+}
+
+func TestConnPoolTestOnBorrow(t *testing.T) {
+	p := &connPool{MaxIdle: 2, TestOnBorrow: time.Nanosecond, Dial: pipeDial, idle: list.New()}
+	c, err := p.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Put(c)
+	time.Sleep(time.Millisecond)
+	// The pipe peer is closed, so the PING fails and a new connection is dialed
+	c2, err := p.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c2 == c {
+		t.Error("dead idle connection should not be handed out")
+	}
+	if stats := p.Stats(); stats.Hits != 0 || stats.Misses != 2 || stats.TotalConns != 1 {
+		t.Errorf("unexpected counters %+v", stats)
+	}
+}
+
+func TestWithContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := r.WithContext(ctx).Ping(); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if r.Context() != context.Background() {
+		t.Error("WithContext should not change the original client")
+	}
+}
+
+func TestWithContextDeadline(t *testing.T) {
+	r.Del("ctx_blpop_key")
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := r.WithContext(ctx).BLPop([]string{"ctx_blpop_key"}, 0)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("BLPop was not interrupted by the context deadline, took %s", elapsed)
+	}
+	if err := r.Ping(); err != nil {
+		t.Errorf("client unusable after interrupted command: %v", err)
+	}
+}
+
 func BenchmarkPackCommandString(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, err := packCommand("SET", "key", "value")
@@ -107,3 +474,25 @@ func BenchmarkPackCommandFloat64(b *testing.B) {
 		}
 	}
 }
+
+func TestStringValueRESP3(t *testing.T) {
+	tests := map[string]string{
+		",1.5\r\n":                  "1.5",
+		",inf\r\n":                  "+Inf",
+		"(12345678901234567890\r\n": "12345678901234567890",
+		"#t\r\n":                    "true",
+		"$3\r\nabc\r\n":             "abc",
+	}
+	for raw, expected := range tests {
+		rp, err := recvFrom(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, err := rp.StringValue(); err != nil || s != expected {
+			t.Errorf("StringValue of %q: %q %v", raw, s, err)
+		}
+		if b, err := rp.BytesValue(); err != nil || string(b) != expected {
+			t.Errorf("BytesValue of %q: %q %v", raw, b, err)
+		}
+	}
+}
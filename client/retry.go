@@ -0,0 +1,153 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/therealbill/libredis/structures"
+)
+
+const (
+	// DefaultRetryAttempts is the default number of times a command is sent
+	DefaultRetryAttempts = 3
+
+	// DefaultMinRetryBackoff is the default delay before the first retry
+	DefaultMinRetryBackoff = 8 * time.Millisecond
+
+	// DefaultMaxRetryBackoff is the default upper bound of the retry delay
+	DefaultMaxRetryBackoff = 512 * time.Millisecond
+)
+
+// RetryPolicy controls how ExecuteCommand retries commands which failed with
+// a transient error, such as a connection closed by the server or a LOADING
+// reply. It is set with DialConfig.RetryPolicy.
+//
+// Error replies mean the server did not run the command, so those are always
+// safe to retry. When the connection fails the command may already have been
+// applied, so only commands COMMAND reports as free of side effects, such as
+// GET or PING, are retried unless RetryWrites is set.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a command is sent, including the
+	// first; 1 disables retries.
+	MaxAttempts int
+	// MinBackoff and MaxBackoff bound the delay between attempts, which
+	// doubles after each attempt and is randomised by up to half.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Retryable reports whether a failed attempt may be retried,
+	// IsRetryable is used when it is nil.
+	Retryable func(err error) bool
+	// RetryWrites also retries commands with side effects, such as INCR or
+	// PUBLISH, after a connection failure.
+	RetryWrites bool
+}
+
+// DefaultRetryPolicy is used when DialConfig.RetryPolicy is nil
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: DefaultRetryAttempts,
+	MinBackoff:  DefaultMinRetryBackoff,
+	MaxBackoff:  DefaultMaxRetryBackoff,
+}
+
+// IsRetryable reports whether err is a transient failure: the connection
+// was closed or reset, or the server replied LOADING, TRYAGAIN or
+// CLUSTERDOWN.
+func IsRetryable(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return true
+	case errors.Is(err, ErrLoading), errors.Is(err, ErrTryAgain), errors.Is(err, ErrClusterDown):
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry, counting from 1
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// shouldRetry reports whether a command which failed with err on the given
+// attempt is sent again
+func (r *Redis) shouldRetry(attempt int, args []interface{}, err error) bool {
+	if attempt >= r.retry.MaxAttempts {
+		return false
+	}
+	retryable := r.retry.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	if !retryable(err) {
+		return false
+	}
+	var rerr *RedisError
+	if errors.As(err, &rerr) {
+		return true
+	}
+	return r.retry.RetryWrites || r.idempotent(args)
+}
+
+// idempotent reports whether a command can be sent twice without changing
+// the outcome, judging by its COMMAND flags. Unknown commands are not.
+func (r *Redis) idempotent(args []interface{}) bool {
+	if len(args) == 0 || r.commands == nil {
+		return false
+	}
+	entry, ok := r.commands.lookup(r, strings.ToLower(argString(args[0])))
+	if !ok {
+		return false
+	}
+	if entry.ReadOnly() {
+		return true
+	}
+	return !entry.Writes() && !entry.Pubsub() && !entry.Flags["may_replicate"] && !entry.Flags["noscript"]
+}
+
+// commandTable holds the COMMAND entries of a server, loaded on first use
+// and shared by all copies of a Redis
+type commandTable struct {
+	mutex   sync.Mutex
+	entries map[string]structures.CommandEntry
+}
+
+// lookup returns the entry of a lower case command name, asking the server
+// for the table if it has not been loaded yet
+func (t *commandTable) lookup(r *Redis, name string) (structures.CommandEntry, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.entries == nil {
+		// Sent without retries, as retrying would look the table up again
+		rr := *r
+		rr.retry = RetryPolicy{}
+		rr.sentinel = nil
+		entries, err := rr.Command()
+		if err != nil {
+			return structures.CommandEntry{}, false
+		}
+		t.entries = make(map[string]structures.CommandEntry, len(entries))
+		for _, entry := range entries {
+			t.entries[strings.ToLower(entry.Name)] = entry
+		}
+	}
+	entry, ok := t.entries[name]
+	return entry, ok
+}
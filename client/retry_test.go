@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/therealbill/libredis/structures"
+)
+
+func TestIsRetryable(t *testing.T) {
+	retryable := []error{
+		io.EOF,
+		fmt.Errorf("read: %w", syscall.ECONNRESET),
+		newRedisError("LOADING Redis is loading the dataset in memory"),
+		newRedisError("TRYAGAIN Multiple keys request during rehashing of slot"),
+		newRedisError("CLUSTERDOWN The cluster is down"),
+	}
+	for _, err := range retryable {
+		if !IsRetryable(err) {
+			t.Errorf("%v should be retryable", err)
+		}
+	}
+	for _, err := range []error{nil, newRedisError("WRONGTYPE Operation against a key"), errors.New("other")} {
+		if IsRetryable(err) {
+			t.Errorf("%v should not be retryable", err)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := DefaultRetryPolicy
+	for retry := 1; retry < 12; retry++ {
+		d := p.backoff(retry)
+		if d < p.MinBackoff/2 || d > p.MaxBackoff {
+			t.Errorf("backoff(%d) = %v out of bounds", retry, d)
+		}
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	rr := &Redis{
+		retry: DefaultRetryPolicy,
+		commands: &commandTable{entries: map[string]structures.CommandEntry{
+			"get":     {Name: "get", Flags: map[string]bool{"readonly": true}},
+			"incr":    {Name: "incr", Flags: map[string]bool{"write": true}},
+			"publish": {Name: "publish", Flags: map[string]bool{"pubsub": true}},
+			"ping":    {Name: "ping", Flags: map[string]bool{"fast": true}},
+		}},
+	}
+	loading := newRedisError("LOADING Redis is loading the dataset in memory")
+	cases := []struct {
+		attempt int
+		args    []interface{}
+		err     error
+		retry   bool
+	}{
+		{1, []interface{}{"GET", "key"}, io.EOF, true},
+		{1, []interface{}{"PING"}, io.EOF, true},
+		{1, []interface{}{"INCR", "key"}, io.EOF, false},
+		{1, []interface{}{"PUBLISH", "ch", "msg"}, io.EOF, false},
+		{1, []interface{}{"UNKNOWN"}, io.EOF, false},
+		{1, []interface{}{"INCR", "key"}, loading, true},
+		{1, []interface{}{"GET", "key"}, newRedisError("WRONGTYPE Operation against a key"), false},
+		{DefaultRetryAttempts, []interface{}{"GET", "key"}, io.EOF, false},
+	}
+	for _, c := range cases {
+		if got := rr.shouldRetry(c.attempt, c.args, c.err); got != c.retry {
+			t.Errorf("shouldRetry(%d, %v, %v) = %v", c.attempt, c.args, c.err, got)
+		}
+	}
+	rr.retry.RetryWrites = true
+	if !rr.shouldRetry(1, []interface{}{"INCR", "key"}, io.EOF) {
+		t.Error("RetryWrites should retry INCR")
+	}
+}
+
+func TestExecuteCommandRetry(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for i := 0; ; i++ {
+			// *2 $3 GET $3 key
+			for j := 0; j < 5; j++ {
+				if _, err := reader.ReadString('\n'); err != nil {
+					return
+				}
+			}
+			if i == 0 {
+				conn.Write([]byte("-LOADING Redis is loading the dataset in memory\r\n"))
+			} else {
+				conn.Write([]byte("$5\r\nvalue\r\n"))
+			}
+		}
+	}()
+	rr, err := DialWithConfig(&DialConfig{Address: ln.Addr().String(), Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	value, err := rr.Get("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "value" {
+		t.Errorf("unexpected value %q", value)
+	}
+}
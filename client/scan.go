@@ -0,0 +1,274 @@
+package client
+
+import (
+	"errors"
+	"iter"
+	"strconv"
+)
+
+// ScanOptions represents options for the SCAN, SSCAN, HSCAN and ZSCAN
+// iterators
+type ScanOptions struct {
+	Match string // MATCH pattern
+	Count int    // COUNT hint of elements per page
+	Type  string // TYPE filter, ScanIter only. Redis 6.0+
+	// NoValues returns the fields without their values, HScanIter only.
+	// Redis 7.4+
+	NoValues bool
+	// Dedupe skips elements already returned, as a cursor may return an
+	// element more than once. It remembers every element returned, so
+	// memory grows with the size of the scan.
+	Dedupe bool
+}
+
+// scanCursor runs the cursor loop shared by the scan iterators
+type scanCursor struct {
+	r      *Redis
+	args   []interface{} // command and key, the cursor is appended
+	opts   ScanOptions
+	step   int // elements per item, 2 for field value pairs
+	cursor uint64
+	done   bool
+	page   []string
+	seen   map[string]struct{}
+	err    error
+}
+
+func newScanCursor(r *Redis, args []interface{}, opts *ScanOptions, step int) scanCursor {
+	c := scanCursor{r: r, args: args, step: step}
+	if opts != nil {
+		c.opts = *opts
+	}
+	if c.opts.Dedupe {
+		c.seen = make(map[string]struct{})
+	}
+	return c
+}
+
+// next returns the next item, fetching pages until one is found or the
+// scan is complete
+func (c *scanCursor) next() ([]string, bool) {
+	for {
+		for len(c.page) >= c.step {
+			item := c.page[:c.step]
+			c.page = c.page[c.step:]
+			if c.seen != nil {
+				if _, ok := c.seen[item[0]]; ok {
+					continue
+				}
+				c.seen[item[0]] = struct{}{}
+			}
+			return item, true
+		}
+		if c.done || c.err != nil {
+			return nil, false
+		}
+		c.fetch()
+	}
+}
+
+// fetch reads the next page
+func (c *scanCursor) fetch() {
+	if c.err = c.r.Context().Err(); c.err != nil {
+		return
+	}
+	args := append(append([]interface{}{}, c.args...), c.cursor)
+	if c.opts.Match != "" {
+		args = append(args, "MATCH", c.opts.Match)
+	}
+	if c.opts.Count > 0 {
+		args = append(args, "COUNT", c.opts.Count)
+	}
+	if c.opts.Type != "" && len(c.args) == 1 {
+		args = append(args, "TYPE", c.opts.Type)
+	}
+	if c.opts.NoValues && c.args[0] == "HSCAN" {
+		args = append(args, "NOVALUES")
+	}
+	rp, err := c.r.ExecuteCommand(args...)
+	if err != nil {
+		c.err = err
+		return
+	}
+	if len(rp.Multi) != 2 {
+		c.err = errors.New("scan protocol error")
+		return
+	}
+	first, err := rp.Multi[0].StringValue()
+	if err != nil {
+		c.err = err
+		return
+	}
+	if c.cursor, c.err = strconv.ParseUint(first, 10, 64); c.err != nil {
+		return
+	}
+	if c.page, c.err = rp.Multi[1].ListValue(); c.err != nil {
+		return
+	}
+	c.done = c.cursor == 0
+}
+
+// ScanIterator iterates over keys or set members, hiding the cursor loop:
+//
+//	it := client.ScanIter(&ScanOptions{Match: "user:*", Type: "hash"})
+//	for it.Next() {
+//		fmt.Println(it.Val())
+//	}
+//	if err := it.Err(); err != nil { ... }
+//
+// The iterator stops with the context error when the context of the client,
+// see WithContext, is done. With a ClusterClient, scan each master with
+// ForEachMaster.
+type ScanIterator struct {
+	c   scanCursor
+	val string
+}
+
+// ScanIter returns an iterator over the keys of the database.
+// SCAN cursor [MATCH pattern] [COUNT count] [TYPE type]
+func (r *Redis) ScanIter(opts *ScanOptions) *ScanIterator {
+	return &ScanIterator{c: newScanCursor(r, []interface{}{"SCAN"}, opts, 1)}
+}
+
+// SScanIter returns an iterator over the members of the set stored at key.
+// SSCAN key cursor [MATCH pattern] [COUNT count]
+func (r *Redis) SScanIter(key string, opts *ScanOptions) *ScanIterator {
+	return &ScanIterator{c: newScanCursor(r, []interface{}{"SSCAN", key}, opts, 1)}
+}
+
+// Next advances to the next element, returning false at the end of the scan
+// or on error
+func (it *ScanIterator) Next() bool {
+	item, ok := it.c.next()
+	if ok {
+		it.val = item[0]
+	}
+	return ok
+}
+
+// Val returns the current element
+func (it *ScanIterator) Val() string {
+	return it.val
+}
+
+// Err returns the error which stopped the iterator, if any
+func (it *ScanIterator) Err() error {
+	return it.c.err
+}
+
+// All returns the remaining elements as an iter.Seq, check Err after the
+// loop:
+//
+//	for key := range it.All() { ... }
+func (it *ScanIterator) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for it.Next() {
+			if !yield(it.val) {
+				return
+			}
+		}
+	}
+}
+
+// HScanIterator iterates over the fields of a hash, see ScanIterator
+type HScanIterator struct {
+	c   scanCursor
+	val HField
+}
+
+// HScanIter returns an iterator over the fields of the hash stored at key.
+// With opts.NoValues the Value of the fields is empty.
+// HSCAN key cursor [MATCH pattern] [COUNT count] [NOVALUES]
+func (r *Redis) HScanIter(key string, opts *ScanOptions) *HScanIterator {
+	step := 2
+	if opts != nil && opts.NoValues {
+		step = 1
+	}
+	return &HScanIterator{c: newScanCursor(r, []interface{}{"HSCAN", key}, opts, step)}
+}
+
+// Next advances to the next field, returning false at the end of the scan or
+// on error
+func (it *HScanIterator) Next() bool {
+	item, ok := it.c.next()
+	if ok {
+		it.val = HField{Field: item[0]}
+		if len(item) > 1 {
+			it.val.Value = item[1]
+		}
+	}
+	return ok
+}
+
+// Val returns the current field
+func (it *HScanIterator) Val() HField {
+	return it.val
+}
+
+// Err returns the error which stopped the iterator, if any
+func (it *HScanIterator) Err() error {
+	return it.c.err
+}
+
+// All returns the remaining fields and values as an iter.Seq2, check Err
+// after the loop
+func (it *HScanIterator) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for it.Next() {
+			if !yield(it.val.Field, it.val.Value) {
+				return
+			}
+		}
+	}
+}
+
+// ZScanIterator iterates over the members of a sorted set, see ScanIterator
+type ZScanIterator struct {
+	c   scanCursor
+	val ZMember
+}
+
+// ZScanIter returns an iterator over the members of the sorted set stored
+// at key.
+// ZSCAN key cursor [MATCH pattern] [COUNT count]
+func (r *Redis) ZScanIter(key string, opts *ScanOptions) *ZScanIterator {
+	return &ZScanIterator{c: newScanCursor(r, []interface{}{"ZSCAN", key}, opts, 2)}
+}
+
+// Next advances to the next member, returning false at the end of the scan
+// or on error
+func (it *ZScanIterator) Next() bool {
+	item, ok := it.c.next()
+	if !ok {
+		return false
+	}
+	score, err := parseDouble(item[1])
+	if err != nil {
+		it.c.err = err
+		return false
+	}
+	it.val = ZMember{Member: item[0], Score: score}
+	return true
+}
+
+// Val returns the current member
+func (it *ZScanIterator) Val() ZMember {
+	return it.val
+}
+
+// Err returns the error which stopped the iterator, if any
+func (it *ZScanIterator) Err() error {
+	return it.c.err
+}
+
+// All returns the remaining members and scores as an iter.Seq2, check Err
+// after the loop
+func (it *ZScanIterator) All() iter.Seq2[string, float64] {
+	return func(yield func(string, float64) bool) {
+		for it.Next() {
+			if !yield(it.val.Member, it.val.Score) {
+				return
+			}
+		}
+	}
+}
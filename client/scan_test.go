@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanIterPages(t *testing.T) {
+	addr, commands := serveReplies(t,
+		"*2\r\n$1\r\n7\r\n*2\r\n$1\r\na\r\n$1\r\nb\r\n",
+		"*2\r\n$1\r\n0\r\n*2\r\n$1\r\nb\r\n$1\r\nc\r\n",
+	)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	it := rr.ScanIter(&ScanOptions{Match: "*", Count: 2, Type: "string", Dedupe: true})
+	var keys []string
+	for key := range it.All() {
+		keys = append(keys, key)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(keys, ",") != "a,b,c" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+	if cmd := <-commands; strings.Join(cmd, " ") != "SCAN 0 MATCH * COUNT 2 TYPE string" {
+		t.Errorf("unexpected first command %v", cmd)
+	}
+	if cmd := <-commands; cmd[1] != "7" {
+		t.Errorf("second page should continue from cursor 7, got %v", cmd)
+	}
+}
+
+func TestZScanIterPairs(t *testing.T) {
+	addr, _ := serveReplies(t, "*2\r\n$1\r\n0\r\n*4\r\n$1\r\na\r\n$3\r\n1.5\r\n$1\r\nb\r\n$3\r\ninf\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	it := rr.ZScanIter("zset", nil)
+	var members []ZMember
+	for it.Next() {
+		members = append(members, it.Val())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 || members[0].Score != 1.5 || members[1].Member != "b" {
+		t.Errorf("unexpected members %v", members)
+	}
+}
+
+func TestScanIterCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it := r.WithContext(ctx).ScanIter(nil)
+	if it.Next() {
+		t.Error("canceled iterator should not return elements")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", it.Err())
+	}
+}
+
+func TestHScanIter(t *testing.T) {
+	r.Del("key")
+	defer r.Del("key")
+	expected := make(map[string]string)
+	for i := 0; i < 50; i++ {
+		field := "field" + strconv.Itoa(i)
+		expected[field] = strconv.Itoa(i)
+		r.HSet("key", field, expected[field])
+	}
+	it := r.HScanIter("key", &ScanOptions{Count: 10, Dedupe: true})
+	got := make(map[string]string)
+	for field, value := range it.All() {
+		got[field] = value
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(expected) {
+		t.Errorf("expected %d fields, got %d", len(expected), len(got))
+	}
+	for field, value := range expected {
+		if got[field] != value {
+			t.Errorf("field %s = %q, should be %q", field, got[field], value)
+		}
+	}
+}
+
+func TestHScanIterNoValues(t *testing.T) {
+	addr, commands := serveReplies(t, "*2\r\n$1\r\n0\r\n*2\r\n$1\r\na\r\n$1\r\nb\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	it := rr.HScanIter("hash", &ScanOptions{NoValues: true})
+	var fields []string
+	for it.Next() {
+		fields = append(fields, it.Val().Field)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(fields, ",") != "a,b" {
+		t.Errorf("unexpected fields %v", fields)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "HSCAN hash 0 NOVALUES" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+}
@@ -1,5 +1,12 @@
 package client
 
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
 // ScriptExists returns information about the existence of the scripts in the script cache.
 // Multi-bulk reply The command returns an array of integers
 // that correspond to the specified SHA1 digest arguments.
@@ -64,3 +71,110 @@ func (r *Redis) EvalSha(sha1 string, keys []string, args []string) (*Reply, erro
 	cmds := packArgs("EVALSHA", sha1, len(keys), keys, args)
 	return r.ExecuteCommand(cmds...)
 }
+
+// EvalRO is the read-only variant of Eval, the script must not modify data.
+// It may run on replicas. Redis 7.0+
+func (r *Redis) EvalRO(script string, keys []string, args []string) (*Reply, error) {
+	cmds := packArgs("EVAL_RO", script, len(keys), keys, args)
+	return r.ExecuteCommand(cmds...)
+}
+
+// EvalShaRO is the read-only variant of EvalSha. Redis 7.0+
+func (r *Redis) EvalShaRO(sha1 string, keys []string, args []string) (*Reply, error) {
+	cmds := packArgs("EVALSHA_RO", sha1, len(keys), keys, args)
+	return r.ExecuteCommand(cmds...)
+}
+
+// Script is a Lua script run by its SHA1 digest.
+// Run sends EVALSHA, and when the server does not have the script cached,
+// such as after a restart or SCRIPT FLUSH, loads it and tries again, so the
+// source is only sent when needed:
+//
+//	var incrBy = NewScript(`return redis.call('INCRBY', KEYS[1], ARGV[1])`, 1)
+//	rp, err := incrBy.Run(client, "counter", 5)
+//
+// A Script is safe for concurrent use.
+type Script struct {
+	src     string
+	hash    string
+	numKeys int
+}
+
+// NewScript returns a Script taking numKeys keys, followed by any number of
+// arguments
+func NewScript(src string, numKeys int) *Script {
+	sum := sha1.Sum([]byte(src))
+	return &Script{src: src, hash: hex.EncodeToString(sum[:]), numKeys: numKeys}
+}
+
+// Hash returns the SHA1 digest of the script
+func (s *Script) Hash() string {
+	return s.hash
+}
+
+// Source returns the source of the script
+func (s *Script) Source() string {
+	return s.src
+}
+
+// Load loads the script into the script cache of the server, or of every
+// master of a ClusterClient
+func (s *Script) Load(r *Redis) error {
+	if r.cluster != nil {
+		return r.cluster.ForEachMaster(func(node *Redis) error {
+			return s.Load(node)
+		})
+	}
+	hash, err := r.ScriptLoad(s.src)
+	if err != nil {
+		return err
+	}
+	if hash != s.hash {
+		return fmt.Errorf("script loaded as %s, expected %s", hash, s.hash)
+	}
+	return nil
+}
+
+// Exists reports whether the script is in the script cache of the server
+func (s *Script) Exists(r *Redis) (bool, error) {
+	exists, err := r.ScriptExists(s.hash)
+	if err != nil {
+		return false, err
+	}
+	return len(exists) == 1 && exists[0], nil
+}
+
+// Run runs the script with EVALSHA, loading it first if the server replies
+// NOSCRIPT. The first numKeys of keysAndArgs are the keys, accessed in the
+// script as KEYS, the rest are the arguments, accessed as ARGV. Arguments may
+// be strings, []byte or numbers.
+func (s *Script) Run(r *Redis, keysAndArgs ...interface{}) (*Reply, error) {
+	return s.run(r, "EVALSHA", "EVAL", keysAndArgs)
+}
+
+// RunRO is the read-only variant of Run, using EVALSHA_RO. The script must
+// not modify data and may run on replicas. Redis 7.0+
+func (s *Script) RunRO(r *Redis, keysAndArgs ...interface{}) (*Reply, error) {
+	return s.run(r, "EVALSHA_RO", "EVAL_RO", keysAndArgs)
+}
+
+func (s *Script) run(r *Redis, cmd, evalCmd string, keysAndArgs []interface{}) (*Reply, error) {
+	if len(keysAndArgs) < s.numKeys {
+		return nil, fmt.Errorf("script takes %d keys, got %d arguments", s.numKeys, len(keysAndArgs))
+	}
+	args := append([]interface{}{cmd, s.hash, s.numKeys}, keysAndArgs...)
+	rp, err := r.ExecuteCommand(args...)
+	if !errors.Is(err, ErrNoScript) {
+		return rp, err
+	}
+	if err := s.Load(r); err != nil {
+		return nil, err
+	}
+	rp, err = r.ExecuteCommand(args...)
+	if !errors.Is(err, ErrNoScript) {
+		return rp, err
+	}
+	// a replica serving read-only scripts does not get SCRIPT LOAD
+	args[0], args[1] = evalCmd, s.src
+	return r.ExecuteCommand(args...)
+}
@@ -1,7 +1,10 @@
 package client
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestEval(t *testing.T) {
@@ -80,3 +83,40 @@ func TestScriptKill(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestScriptRun(t *testing.T) {
+	script := NewScript("return redis.call('INCRBY', KEYS[1], ARGV[1])", 1)
+	if script.Hash() != "8cd00688c05c46bde4a2e60658ef20a2e5c0b248" {
+		t.Fatalf("unexpected hash %s", script.Hash())
+	}
+	addr, commands := serveReplies(t,
+		"-NOSCRIPT No matching script. Please use EVAL.\r\n",
+		fmt.Sprintf("$40\r\n%s\r\n", script.Hash()),
+		":7\r\n",
+	)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	rp, err := script.Run(rr, "counter", []byte("5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := rp.IntegerValue(); err != nil || n != 7 {
+		t.Errorf("unexpected reply %d %v", n, err)
+	}
+	expected := []string{
+		"EVALSHA " + script.Hash() + " 1 counter 5",
+		"SCRIPT LOAD " + script.Source(),
+		"EVALSHA " + script.Hash() + " 1 counter 5",
+	}
+	for _, e := range expected {
+		if cmd := strings.Join(<-commands, " "); cmd != e {
+			t.Errorf("expected %q, got %q", e, cmd)
+		}
+	}
+	if _, err := script.Run(rr); err == nil {
+		t.Error("expected error for missing keys")
+	}
+}
@@ -0,0 +1,341 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/therealbill/libredis/structures"
+)
+
+// SentinelClient is a Redis client for a master monitored by Sentinel.
+// It subscribes to +switch-master on the sentinels and, after a failover,
+// checks the new master with ROLE and switches its connection pool over to
+// it, so callers keep using the same client across failovers.
+//
+// All command methods of Redis are available on SentinelClient.
+type SentinelClient struct {
+	*Redis
+
+	// ReadFromReplicas sends read-only commands to the replicas of the
+	// master, chosen round robin, when any are known. Set it before use.
+	ReadFromReplicas bool
+
+	masterName    string
+	sentinelAddrs []string
+	cfg           DialConfig
+	mutex         sync.RWMutex
+	masterAddr    string
+	replicas      []*Redis
+	loaded        int32
+	next          uint32
+	commands      map[string]structures.CommandEntry
+	pubsub        *PubSub
+	closed        chan struct{}
+}
+
+// DialSentinel asks the given sentinels for the address of masterName and
+// connects to it. cfg is used for the master and replica connections, with
+// Address replaced; it may be nil. Sentinels are dialed with cfg but without
+// Password and Database.
+func DialSentinel(masterName string, sentinelAddrs []string, cfg *DialConfig) (*SentinelClient, error) {
+	if len(sentinelAddrs) == 0 {
+		return nil, errors.New("no sentinel addresses given")
+	}
+	sc := &SentinelClient{
+		masterName:    masterName,
+		sentinelAddrs: sentinelAddrs,
+		closed:        make(chan struct{}),
+	}
+	if cfg != nil {
+		sc.cfg = *cfg
+	}
+	addr, err := sc.discoverMaster()
+	if err != nil {
+		return nil, err
+	}
+	master, err := sc.dialMaster(addr)
+	if err != nil {
+		return nil, err
+	}
+	master.sentinel = sc
+	sc.Redis = master
+	sc.masterAddr = addr
+	if entries, err := master.Command(); err == nil {
+		sc.commands = make(map[string]structures.CommandEntry, len(entries))
+		for _, entry := range entries {
+			sc.commands[strings.ToLower(entry.Name)] = entry
+		}
+	}
+	go sc.watch()
+	return sc, nil
+}
+
+// MasterAddress returns the address of the current master
+func (sc *SentinelClient) MasterAddress() string {
+	sc.mutex.RLock()
+	defer sc.mutex.RUnlock()
+	return sc.masterAddr
+}
+
+// Address returns the address of the current master
+func (sc *SentinelClient) Address() string {
+	return sc.MasterAddress()
+}
+
+// GetName returns the address of the current master
+func (sc *SentinelClient) GetName() string {
+	return sc.MasterAddress()
+}
+
+// ClosePool stops following failovers and closes the master and replica
+// connection pools
+func (sc *SentinelClient) ClosePool() {
+	sc.mutex.Lock()
+	select {
+	case <-sc.closed:
+	default:
+		close(sc.closed)
+	}
+	if sc.pubsub != nil {
+		sc.pubsub.Close()
+	}
+	for _, replica := range sc.replicas {
+		replica.ClosePool()
+	}
+	sc.replicas = nil
+	sc.mutex.Unlock()
+	sc.Redis.ClosePool()
+}
+
+// dialSentinel connects to a single sentinel
+func (sc *SentinelClient) dialSentinel(addr string) (*Redis, error) {
+	cfg := sc.cfg
+	cfg.Address = addr
+	cfg.Password = ""
+	cfg.Database = 0
+	return DialWithConfig(&cfg)
+}
+
+// discoverMaster returns the master address reported by the first sentinel
+// which knows about the master
+func (sc *SentinelClient) discoverMaster() (string, error) {
+	var lastErr error
+	for _, addr := range sc.sentinelAddrs {
+		sentinel, err := sc.dialSentinel(addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		master, err := sentinel.SentinelGetMaster(sc.masterName)
+		sentinel.ClosePool()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if master.Host == "" {
+			lastErr = fmt.Errorf("sentinel %s does not know master %s", addr, sc.masterName)
+			continue
+		}
+		return master.Host + ":" + strconv.Itoa(master.Port), nil
+	}
+	return "", lastErr
+}
+
+// dialMaster connects to addr and checks with ROLE that it is a master
+func (sc *SentinelClient) dialMaster(addr string) (*Redis, error) {
+	cfg := sc.cfg
+	cfg.Address = addr
+	master, err := DialWithConfig(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	role, err := master.RoleName()
+	if err != nil {
+		master.ClosePool()
+		return nil, err
+	}
+	if role != "master" {
+		master.ClosePool()
+		return nil, fmt.Errorf("%s reports role %s, not master", addr, role)
+	}
+	return master, nil
+}
+
+// switchMaster moves the connection pool over to the master at addr.
+// A freshly promoted replica may take a moment to report itself as master,
+// so the ROLE check is retried a few times.
+func (sc *SentinelClient) switchMaster(addr string) error {
+	if addr == sc.MasterAddress() {
+		return nil
+	}
+	var master *Redis
+	var err error
+	for i := 0; i < 5; i++ {
+		if master, err = sc.dialMaster(addr); err == nil {
+			break
+		}
+		time.Sleep(time.Duration(i+1) * 100 * time.Millisecond)
+	}
+	if err != nil {
+		return err
+	}
+	// Only the dial function of the new master is kept, connections to it
+	// are made by the existing pool so that all users of the pool follow.
+	master.ClosePool()
+	sc.mutex.Lock()
+	sc.masterAddr = addr
+	sc.Redis.pool.reset(master.dialConnection)
+	sc.mutex.Unlock()
+	if atomic.LoadInt32(&sc.loaded) == 1 {
+		sc.refreshReplicas()
+	}
+	return nil
+}
+
+// watch follows the sentinel event channels until the client is closed,
+// moving on to the next sentinel when the current one is unreachable
+func (sc *SentinelClient) watch() {
+	for i := 0; ; i++ {
+		select {
+		case <-sc.closed:
+			return
+		default:
+		}
+		addr := sc.sentinelAddrs[i%len(sc.sentinelAddrs)]
+		if err := sc.watchSentinel(addr); err != nil {
+			time.Sleep(sc.cfg.Timeout + 100*time.Millisecond)
+		}
+	}
+}
+
+// watchSentinel subscribes to the failover and replica events of one
+// sentinel and handles them until the subscription fails
+func (sc *SentinelClient) watchSentinel(addr string) error {
+	sentinel, err := sc.dialSentinel(addr)
+	if err != nil {
+		return err
+	}
+	defer sentinel.ClosePool()
+	p, err := sentinel.PubSub()
+	if err != nil {
+		return err
+	}
+	// Events arrive at any time, so the subscription must not time out
+	p.conn.Conn.SetDeadline(time.Time{})
+	if err := p.Subscribe("+switch-master", "+slave", "+sdown", "-sdown"); err != nil {
+		p.Close()
+		return err
+	}
+	sc.mutex.Lock()
+	select {
+	case <-sc.closed:
+		sc.mutex.Unlock()
+		p.Close()
+		return nil
+	default:
+	}
+	sc.pubsub = p
+	sc.mutex.Unlock()
+	defer p.Close()
+
+	// A failover may have happened while no sentinel was being watched
+	if master, err := sentinel.SentinelGetMaster(sc.masterName); err == nil && master.Host != "" {
+		sc.switchMaster(master.Host + ":" + strconv.Itoa(master.Port))
+	}
+	for {
+		msg, err := p.Receive()
+		if err != nil {
+			return err
+		}
+		if msg[0] != "message" {
+			continue
+		}
+		fields := strings.Fields(msg[2])
+		switch msg[1] {
+		case "+switch-master":
+			// <master name> <old ip> <old port> <new ip> <new port>
+			if len(fields) == 5 && fields[0] == sc.masterName {
+				sc.switchMaster(fields[3] + ":" + fields[4])
+			}
+		default:
+			// <instance type> <name> <ip> <port> @ <master name> <master ip> <master port>
+			if len(fields) >= 6 && fields[0] == "slave" && fields[5] == sc.masterName && atomic.LoadInt32(&sc.loaded) == 1 {
+				sc.refreshReplicas()
+			}
+		}
+	}
+}
+
+// refreshReplicas updates the replica pools from SENTINEL SLAVES, keeping
+// only replicas which are up and linked to the master
+func (sc *SentinelClient) refreshReplicas() {
+	var infos []structures.SlaveInfo
+	for _, addr := range sc.sentinelAddrs {
+		sentinel, err := sc.dialSentinel(addr)
+		if err != nil {
+			continue
+		}
+		infos, err = sentinel.SentinelSlaves(sc.masterName)
+		sentinel.ClosePool()
+		if err == nil {
+			break
+		}
+	}
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	current := make(map[string]*Redis, len(sc.replicas))
+	for _, replica := range sc.replicas {
+		current[replica.Address()] = replica
+	}
+	var replicas []*Redis
+	for _, info := range infos {
+		if strings.Contains(info.Flags, "s_down") || strings.Contains(info.Flags, "disconnected") || info.MasterLinkStatus != "ok" {
+			continue
+		}
+		addr := info.Host + ":" + strconv.Itoa(info.Port)
+		if replica, ok := current[addr]; ok {
+			replicas = append(replicas, replica)
+			delete(current, addr)
+			continue
+		}
+		cfg := sc.cfg
+		cfg.Address = addr
+		replica, err := DialWithConfig(&cfg)
+		if err != nil {
+			continue
+		}
+		replicas = append(replicas, replica)
+	}
+	for _, replica := range current {
+		replica.ClosePool()
+	}
+	sc.replicas = replicas
+}
+
+// replicaFor returns the replica a command should be sent to, or nil when it
+// should go to the master
+func (sc *SentinelClient) replicaFor(args []interface{}) *Redis {
+	if !sc.ReadFromReplicas || len(args) == 0 {
+		return nil
+	}
+	entry, ok := sc.commands[strings.ToLower(argString(args[0]))]
+	if !ok || !entry.ReadOnly() || entry.Writes() {
+		return nil
+	}
+	// Replicas are looked up on the first read sent to them
+	if atomic.CompareAndSwapInt32(&sc.loaded, 0, 1) {
+		sc.refreshReplicas()
+	}
+	sc.mutex.RLock()
+	defer sc.mutex.RUnlock()
+	if len(sc.replicas) == 0 {
+		return nil
+	}
+	n := atomic.AddUint32(&sc.next, 1)
+	return sc.replicas[int(n)%len(sc.replicas)]
+}
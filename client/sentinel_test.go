@@ -1,10 +1,14 @@
 package client
 
-import "testing"
+import (
+	"context"
+	"testing"
+	"time"
+)
 
 func init() {
 	address = "127.0.0.1:6379"
-	client, err := DialWithConfig(&DialConfig{network, address, db, password, timeout, maxidle, false, false, "", "", "", "", tcpKeepAlive})
+	client, err := DialWithConfig(&DialConfig{Network: network, Address: address, Database: db, Password: password, Timeout: timeout, MaxIdle: maxidle, TCPKeepAlive: tcpKeepAlive})
 	if err != nil {
 		panic(err)
 	}
@@ -87,3 +91,44 @@ func TestBuildMasterInfo(t *testing.T) {
 	}
 
 }
+
+func TestSentinelSwitchMaster(t *testing.T) {
+	oldAddr, _ := serveReplies(t)
+	master, err := DialWithConfig(&DialConfig{Address: oldAddr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := &SentinelClient{Redis: master, masterAddr: oldAddr, cfg: DialConfig{Timeout: time.Second}, closed: make(chan struct{})}
+	defer sc.ClosePool()
+
+	// commands keep copying the client while the watcher switches masters
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			sc.WithContext(context.Background()).GetName()
+			sc.Redis.GetName()
+			sc.GetName()
+		}
+	}()
+	var newAddr string
+	for i := 0; i < 5; i++ {
+		var commands <-chan []string
+		newAddr, commands = serveReplies(t, "*3\r\n$6\r\nmaster\r\n:0\r\n*0\r\n")
+		if err := sc.switchMaster(newAddr); err != nil {
+			t.Fatal(err)
+		}
+		<-commands
+	}
+	close(stop)
+	<-done
+	if sc.Address() != newAddr || sc.GetName() != newAddr {
+		t.Errorf("expected the address of the new master %s, got %s", newAddr, sc.Address())
+	}
+}
@@ -264,13 +264,16 @@ func (r *Redis) Monitor() (*MonitorCommand, error) {
 		return nil, err
 	}
 	if err := c.SendCommand("MONITOR"); err != nil {
+		r.pool.Discard(c)
 		return nil, err
 	}
 	rp, err := c.RecvReply()
 	if err != nil {
+		r.pool.Discard(c)
 		return nil, err
 	}
 	if err := rp.OKValue(); err != nil {
+		r.pool.Put(c)
 		return nil, err
 	}
 	return &MonitorCommand{r, c}, nil
@@ -287,7 +290,9 @@ func (m *MonitorCommand) Receive() (string, error) {
 
 // Close closes current monitor command.
 func (m *MonitorCommand) Close() error {
-	return m.conn.SendCommand("QUIT")
+	err := m.conn.SendCommand("QUIT")
+	m.redis.pool.Discard(m.conn)
+	return err
 }
 
 // Save performs a synchronous save of the dataset
@@ -362,7 +367,7 @@ func (r *Redis) SlowLogGet(n int64) ([]*SlowLog, error) {
 		return nil, err
 	}
 	if rp.Type == ErrorReply {
-		return nil, errors.New(rp.Error)
+		return nil, newRedisError(rp.Error)
 	}
 	if rp.Type != MultiReply {
 		return nil, errors.New("slowlog get protocol error")
@@ -310,7 +310,7 @@ func TestLatencyHistory(t *testing.T) {
 		return
 	}
 
-	t.Logf("Latency history for '%s': %d samples", event, len(samples))
+	t.Logf("Latency history for '%s': %d samples", event, len(samples.Records))
 }
 
 func TestLatencyReset(t *testing.T) {
@@ -365,7 +365,7 @@ func TestSwapDB(t *testing.T) {
 	}
 
 	// After swap, our key should be in database 2 and not visible
-	value, err := r.Get("swap_test_key")
+	_, err = r.Get("swap_test_key")
 	if err != nil {
 		t.Error(err)
 	}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"errors"
 	"strconv"
 )
 
@@ -227,3 +228,168 @@ func (r *Redis) SMIsMember(key string, members ...string) ([]bool, error) {
 	
 	return nil, nil
 }
+
+// SINTERCARD numkeys key [key ...] [LIMIT limit]
+// SInterCard returns the number of members in the intersection of the sets,
+// counting up to limit when it is not 0.
+// Redis 7.0+
+func (r *Redis) SInterCard(keys []string, limit int64) (int64, error) {
+	args := packArgs("SINTERCARD", len(keys), keys)
+	if limit > 0 {
+		args = append(args, "LIMIT", limit)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// SPOP key count
+// SPopCount removes and returns up to count random members of the set stored
+// at key, an empty list when key does not exist.
+// Redis 3.2+
+func (r *Redis) SPopCount(key string, count int) ([]string, error) {
+	rp, err := r.ExecuteCommand("SPOP", key, count)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// SPopCountBytes is SPopCount returning binary members.
+// Redis 3.2+
+func (r *Redis) SPopCountBytes(key string, count int) ([][]byte, error) {
+	rp, err := r.ExecuteCommand("SPOP", key, count)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesArrayValue()
+}
+
+// SRandMemberCountBytes is SRandMemberCount returning binary members.
+func (r *Redis) SRandMemberCountBytes(key string, count int) ([][]byte, error) {
+	rp, err := r.ExecuteCommand("SRANDMEMBER", key, count)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesArrayValue()
+}
+
+// SAddBytes is SAdd with binary members.
+func (r *Redis) SAddBytes(key string, members ...[]byte) (int64, error) {
+	args := packArgs("SADD", key, members)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// SRemBytes is SRem with binary members.
+func (r *Redis) SRemBytes(key string, members ...[]byte) (int64, error) {
+	args := packArgs("SREM", key, members)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// SIsMemberBytes is SIsMember with a binary member.
+func (r *Redis) SIsMemberBytes(key string, member []byte) (bool, error) {
+	rp, err := r.ExecuteCommand("SISMEMBER", key, member)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// SMIsMemberBytes is SMIsMember with binary members.
+// Redis 6.2.0+
+func (r *Redis) SMIsMemberBytes(key string, members ...[]byte) ([]bool, error) {
+	args := packArgs("SMISMEMBER", key, members)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BoolArrayValue()
+}
+
+// SMoveBytes is SMove with a binary member.
+func (r *Redis) SMoveBytes(source, destination string, member []byte) (bool, error) {
+	rp, err := r.ExecuteCommand("SMOVE", source, destination, member)
+	if err != nil {
+		return false, err
+	}
+	return rp.BoolValue()
+}
+
+// SMembersBytes is SMembers returning binary members.
+func (r *Redis) SMembersBytes(key string) ([][]byte, error) {
+	rp, err := r.ExecuteCommand("SMEMBERS", key)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesArrayValue()
+}
+
+// SInterBytes is SInter returning binary members.
+func (r *Redis) SInterBytes(keys ...string) ([][]byte, error) {
+	args := packArgs("SINTER", keys)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesArrayValue()
+}
+
+// SUnionBytes is SUnion returning binary members.
+func (r *Redis) SUnionBytes(keys ...string) ([][]byte, error) {
+	args := packArgs("SUNION", keys)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesArrayValue()
+}
+
+// SDiffBytes is SDiff returning binary members.
+func (r *Redis) SDiffBytes(keys ...string) ([][]byte, error) {
+	args := packArgs("SDIFF", keys)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesArrayValue()
+}
+
+// SScanBytes is SScan returning binary members. SSCAN has no TYPE filter,
+// which only applies to the keys of SCAN.
+// SSCAN key cursor [MATCH pattern] [COUNT count]
+func (r *Redis) SScanBytes(key string, cursor uint64, pattern string, count int) (uint64, [][]byte, error) {
+	args := packArgs("SSCAN", key, cursor)
+	if pattern != "" {
+		args = append(args, "MATCH", pattern)
+	}
+	if count > 0 {
+		args = append(args, "COUNT", count)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rp.Multi) != 2 {
+		return 0, nil, errors.New("scan protocol error")
+	}
+	first, err := rp.Multi[0].StringValue()
+	if err != nil {
+		return 0, nil, err
+	}
+	next, err := strconv.ParseUint(first, 10, 64)
+	if err != nil {
+		return 0, nil, err
+	}
+	members, err := rp.Multi[1].BytesArrayValue()
+	return next, members, err
+}
@@ -1,7 +1,10 @@
 package client
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSAdd(t *testing.T) {
@@ -206,3 +209,57 @@ func TestSMIsMember(t *testing.T) {
 		t.Error("Unexpected membership results")
 	}
 }
+
+func TestSInterCardSPopCount(t *testing.T) {
+	addr, commands := serveReplies(t, ":2\r\n", "*2\r\n$1\r\na\r\n$1\r\nb\r\n", "*0\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	if n, err := rr.SInterCard([]string{"s1", "s2"}, 5); err != nil || n != 2 {
+		t.Errorf("unexpected result %d, %v", n, err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "SINTERCARD 2 s1 s2 LIMIT 5" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	members, err := rr.SPopCount("s1", 2)
+	if err != nil || strings.Join(members, ",") != "a,b" || cap(members) != 2 {
+		t.Errorf("unexpected result %v, %v", members, err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "SPOP s1 2" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if members, err := rr.SPopCount("missing", 2); err != nil || len(members) != 0 {
+		t.Errorf("unexpected result %v, %v", members, err)
+	}
+}
+
+func TestSetBytesVariants(t *testing.T) {
+	id := []byte{0x00, 0xff, '\r', '\n'}
+	addr, commands := serveReplies(t,
+		":1\r\n",
+		"*2\r\n:1\r\n:0\r\n",
+		"*2\r\n$1\r\n0\r\n*1\r\n$4\r\n\x00\xff\r\n\r\n",
+	)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	if n, err := rr.SAddBytes("ids", id); err != nil || n != 1 {
+		t.Errorf("unexpected result %d, %v", n, err)
+	}
+	if cmd := <-commands; len(cmd) != 3 || cmd[2] != string(id) {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	found, err := rr.SMIsMemberBytes("ids", id, []byte("other"))
+	if err != nil || len(found) != 2 || !found[0] || found[1] {
+		t.Errorf("unexpected result %v, %v", found, err)
+	}
+	<-commands
+	cursor, members, err := rr.SScanBytes("ids", 0, "", 0)
+	if err != nil || cursor != 0 || len(members) != 1 || !bytes.Equal(members[0], id) {
+		t.Errorf("unexpected result %d, %q, %v", cursor, members, err)
+	}
+}
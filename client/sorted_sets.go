@@ -67,15 +67,7 @@ func (r *Redis) ZIncrBy(key string, increment float64, member string) (float64,
 	if err != nil {
 		return 0.0, err
 	}
-	s, err := rp.StringValue()
-	if err != nil {
-		return 0.0, err
-	}
-	score, err := strconv.ParseFloat(s, 64)
-	if err != nil {
-		return 0.0, err
-	}
-	return score, nil
+	return rp.DoubleValue()
 }
 
 // ZInterStore destination numkeys key [key ...] [WEIGHTS weight [weight ...]] [AGGREGATE SUM|MIN|MAX]
@@ -123,7 +115,7 @@ func (r *Redis) ZRange(key string, start, stop int, withscores bool) ([]string,
 	if err != nil {
 		return nil, err
 	}
-	return rp.ListValue()
+	return zListValue(rp)
 }
 
 // ZRangeByLex returns all the elements in the sorted set at key with a value between min and max
@@ -153,7 +145,7 @@ func (r *Redis) ZRangeByScore(key, min, max string, withscores, limit bool, offs
 	if err != nil {
 		return nil, err
 	}
-	return rp.ListValue()
+	return zListValue(rp)
 }
 
 // ZRank returns the rank of member in the sorted set stored at key,
@@ -169,7 +161,7 @@ func (r *Redis) ZRank(key, member string) (int64, error) {
 		return -1, err
 	}
 	if rp.Type == ErrorReply {
-		return -1, errors.New(rp.Error)
+		return -1, newRedisError(rp.Error)
 	}
 	if rp.Type == IntegerReply {
 		return rp.Integer, nil
@@ -239,7 +231,7 @@ func (r *Redis) ZRevRange(key string, start, stop int, withscores bool) ([]strin
 	if err != nil {
 		return nil, err
 	}
-	return rp.ListValue()
+	return zListValue(rp)
 }
 
 // ZRevRangeByScore key max min [WITHSCORES] [LIMIT offset count]
@@ -255,7 +247,7 @@ func (r *Redis) ZRevRangeByScore(key, max, min string, withscores, limit bool, o
 	if err != nil {
 		return nil, err
 	}
-	return rp.ListValue()
+	return zListValue(rp)
 }
 
 // ZRevRank returns the rank of member in the sorted set stored at key,
@@ -267,7 +259,7 @@ func (r *Redis) ZRevRank(key, member string) (int64, error) {
 		return -1, err
 	}
 	if rp.Type == ErrorReply {
-		return -1, errors.New(rp.Error)
+		return -1, newRedisError(rp.Error)
 	}
 	if rp.Type == IntegerReply {
 		return rp.Integer, nil
@@ -595,28 +587,8 @@ func (r *Redis) ZRandMemberWithOptions(key string, opts ZRandMemberOptions) ([]Z
 	
 	if rp.Type == MultiReply {
 		if opts.WithScores {
-			result := make([]ZMember, 0, len(rp.Multi)/2)
-			for i := 0; i < len(rp.Multi); i += 2 {
-				if i+1 < len(rp.Multi) {
-					member, err := rp.Multi[i].StringValue()
-					if err != nil {
-						continue
-					}
-					
-					scoreStr, err := rp.Multi[i+1].StringValue()
-					if err != nil {
-						continue
-					}
-					
-					score, err := strconv.ParseFloat(scoreStr, 64)
-					if err != nil {
-						continue
-					}
-					
-					result = append(result, ZMember{Member: member, Score: score})
-				}
-			}
-			return result, nil
+			// flat in RESP2, member score pairs in RESP3
+			return zMembersValue(rp)
 		} else {
 			result := make([]ZMember, 0, len(rp.Multi))
 			for _, item := range rp.Multi {
@@ -646,14 +618,8 @@ func (r *Redis) ZMScore(key string, members ...string) ([]float64, error) {
 	if rp.Type == MultiReply {
 		result := make([]float64, len(rp.Multi))
 		for i, item := range rp.Multi {
-			if item.Type == BulkReply && item.Bulk != nil {
-				scoreStr, err := item.StringValue()
-				if err != nil {
-					result[i] = 0
-					continue
-				}
-				
-				score, err := strconv.ParseFloat(scoreStr, 64)
+			if !item.IsNull() {
+				score, err := item.DoubleValue()
 				if err != nil {
 					result[i] = 0
 					continue
@@ -669,3 +635,411 @@ func (r *Redis) ZMScore(key string, members ...string) ([]float64, error) {
 	
 	return nil, nil
 }
+
+// Sorted set constants
+const (
+	ZAggregateSum = "SUM"
+	ZAggregateMin = "MIN"
+	ZAggregateMax = "MAX"
+
+	ZMPopMin = "MIN"
+	ZMPopMax = "MAX"
+)
+
+// zMembersValue reads members with their scores, a flat member score list
+// in RESP2 and a list of member score pairs in RESP3
+func zMembersValue(rp *Reply) ([]ZMember, error) {
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	if len(multi) > 0 && multi[0].isAggregate() {
+		members := make([]ZMember, 0, len(multi))
+		for _, pair := range multi {
+			if len(pair.Multi) != 2 {
+				return nil, errors.New("invalid reply, not a member score pair")
+			}
+			m, err := zMemberValue(pair.Multi[0], pair.Multi[1])
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, m)
+		}
+		return members, nil
+	}
+	if len(multi)%2 != 0 {
+		return nil, errors.New("invalid reply, not a list of member score pairs")
+	}
+	members := make([]ZMember, 0, len(multi)/2)
+	for i := 0; i < len(multi); i += 2 {
+		m, err := zMemberValue(multi[i], multi[i+1])
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+func zMemberValue(member, score *Reply) (ZMember, error) {
+	m, err := member.StringValue()
+	if err != nil {
+		return ZMember{}, err
+	}
+	s, err := score.DoubleValue()
+	if err != nil {
+		return ZMember{}, err
+	}
+	return ZMember{Member: m, Score: s}, nil
+}
+
+// zListValue reads a list of members, followed by their scores with
+// WITHSCORES, flattening the member score pairs of RESP3
+func zListValue(rp *Reply) ([]string, error) {
+	multi, err := rp.MultiValue()
+	if err != nil {
+		return nil, err
+	}
+	if len(multi) == 0 || !multi[0].isAggregate() {
+		return rp.ListValue()
+	}
+	list := make([]string, 0, 2*len(multi))
+	for _, pair := range multi {
+		if len(pair.Multi) != 2 {
+			return nil, errors.New("invalid reply, not a member score pair")
+		}
+		for _, item := range pair.Multi {
+			s, err := item.keyString()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, s)
+		}
+	}
+	return list, nil
+}
+
+// zMembersList reads members without scores
+func zMembersList(rp *Reply) ([]ZMember, error) {
+	list, err := rp.ListValue()
+	if err != nil {
+		return nil, err
+	}
+	members := make([]ZMember, len(list))
+	for i, m := range list {
+		members[i].Member = m
+	}
+	return members, nil
+}
+
+// ZAddOptions represents options for ZADD command
+type ZAddOptions struct {
+	NX bool // NX option - only add new members
+	XX bool // XX option - only update existing members
+	GT bool // GT option - only update when the new score is greater (Redis 6.2+)
+	LT bool // LT option - only update when the new score is less (Redis 6.2+)
+	CH bool // CH option - count the changed members, not only the added ones
+}
+
+func (opts ZAddOptions) args(args []interface{}) []interface{} {
+	if opts.NX {
+		args = append(args, "NX")
+	}
+	if opts.XX {
+		args = append(args, "XX")
+	}
+	if opts.GT {
+		args = append(args, "GT")
+	}
+	if opts.LT {
+		args = append(args, "LT")
+	}
+	if opts.CH {
+		args = append(args, "CH")
+	}
+	return args
+}
+
+// ZADD key [NX|XX] [GT|LT] [CH] score member [score member ...]
+// ZAddWithOptions adds or updates members with the options of ZADD.
+// Returns the number of members added, or changed with CH.
+func (r *Redis) ZAddWithOptions(key string, members []ZMember, opts ZAddOptions) (int64, error) {
+	args := opts.args([]interface{}{"ZADD", key})
+	for _, m := range members {
+		args = append(args, m.Score, m.Member)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ZADD key [NX|XX] [GT|LT] INCR increment member
+// ZAddIncr increments the score of member like ZIncrBy, with the conditions
+// of ZADD. Returns the new score, or ErrNil if a condition prevented the
+// update.
+func (r *Redis) ZAddIncr(key, member string, increment float64, opts ZAddOptions) (float64, error) {
+	opts.CH = false
+	args := opts.args([]interface{}{"ZADD", key})
+	rp, err := r.ExecuteCommand(append(args, "INCR", increment, member)...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.DoubleValue()
+}
+
+// ZRangeOptions represents options for the unified ZRANGE command
+type ZRangeOptions struct {
+	ByScore bool // BYSCORE option - start and stop are scores such as "(1" or "+inf"
+	ByLex   bool // BYLEX option - start and stop are ranges such as "[a" or "-"
+	Rev     bool // REV option - highest first, start is then the higher end
+	// Offset and Count set the LIMIT option with ByScore or ByLex when Count
+	// is not 0, a negative Count returns all the elements from Offset
+	Offset int64
+	Count  int64
+}
+
+func (opts ZRangeOptions) args(args []interface{}) []interface{} {
+	if opts.ByScore {
+		args = append(args, "BYSCORE")
+	}
+	if opts.ByLex {
+		args = append(args, "BYLEX")
+	}
+	if opts.Rev {
+		args = append(args, "REV")
+	}
+	if opts.Count != 0 {
+		args = append(args, "LIMIT", opts.Offset, opts.Count)
+	}
+	return args
+}
+
+// ZRANGE key start stop [BYSCORE|BYLEX] [REV] [LIMIT offset count] [WITHSCORES]
+// ZRangeWithOptions returns a range of members by rank, or by score or
+// lexicographical order, with their scores except with ByLex. start and stop
+// are ranks such as "0" and "-1" unless ByScore or ByLex is set.
+// Redis 6.2+
+func (r *Redis) ZRangeWithOptions(key, start, stop string, opts ZRangeOptions) ([]ZMember, error) {
+	args := opts.args([]interface{}{"ZRANGE", key, start, stop})
+	if opts.ByLex {
+		rp, err := r.ExecuteCommand(args...)
+		if err != nil {
+			return nil, err
+		}
+		return zMembersList(rp)
+	}
+	rp, err := r.ExecuteCommand(append(args, "WITHSCORES")...)
+	if err != nil {
+		return nil, err
+	}
+	return zMembersValue(rp)
+}
+
+// ZRANGESTORE dst src min max [BYSCORE|BYLEX] [REV] [LIMIT offset count]
+// ZRangeStore stores the range of src selected as by ZRangeWithOptions in
+// destination. Returns the number of members stored.
+// Redis 6.2+
+func (r *Redis) ZRangeStore(destination, source, start, stop string, opts ZRangeOptions) (int64, error) {
+	args := opts.args([]interface{}{"ZRANGESTORE", destination, source, start, stop})
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ZAggregateOptions represents the WEIGHTS and AGGREGATE options of
+// ZINTER, ZUNION and their STORE variants
+type ZAggregateOptions struct {
+	Weights   []float64 // WEIGHTS option - a weight per key
+	Aggregate string    // AGGREGATE option - ZAggregateSum, ZAggregateMin or ZAggregateMax
+}
+
+func (opts ZAggregateOptions) args(args []interface{}) []interface{} {
+	if len(opts.Weights) > 0 {
+		args = append(args, "WEIGHTS")
+		for _, w := range opts.Weights {
+			args = append(args, w)
+		}
+	}
+	if opts.Aggregate != "" {
+		args = append(args, "AGGREGATE", opts.Aggregate)
+	}
+	return args
+}
+
+// ZINTER numkeys key [key ...] [WEIGHTS weight [weight ...]] [AGGREGATE SUM|MIN|MAX]
+// ZInter returns the members of the intersection of the sorted sets.
+// Redis 6.2+
+func (r *Redis) ZInter(keys []string, opts ZAggregateOptions) ([]string, error) {
+	args := opts.args(packArgs("ZINTER", len(keys), keys))
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// ZInterWithScores returns the intersection of the sorted sets with the
+// aggregated scores.
+// Redis 6.2+
+func (r *Redis) ZInterWithScores(keys []string, opts ZAggregateOptions) ([]ZMember, error) {
+	args := opts.args(packArgs("ZINTER", len(keys), keys))
+	rp, err := r.ExecuteCommand(append(args, "WITHSCORES")...)
+	if err != nil {
+		return nil, err
+	}
+	return zMembersValue(rp)
+}
+
+// ZInterStoreWithOptions is ZInterStore with float weights.
+func (r *Redis) ZInterStoreWithOptions(destination string, keys []string, opts ZAggregateOptions) (int64, error) {
+	args := opts.args(packArgs("ZINTERSTORE", destination, len(keys), keys))
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ZINTERCARD numkeys key [key ...] [LIMIT limit]
+// ZInterCard returns the number of members in the intersection of the
+// sorted sets, counting up to limit when it is not 0.
+// Redis 7.0+
+func (r *Redis) ZInterCard(keys []string, limit int64) (int64, error) {
+	args := packArgs("ZINTERCARD", len(keys), keys)
+	if limit > 0 {
+		args = append(args, "LIMIT", limit)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ZUNION numkeys key [key ...] [WEIGHTS weight [weight ...]] [AGGREGATE SUM|MIN|MAX]
+// ZUnion returns the members of the union of the sorted sets.
+// Redis 6.2+
+func (r *Redis) ZUnion(keys []string, opts ZAggregateOptions) ([]string, error) {
+	args := opts.args(packArgs("ZUNION", len(keys), keys))
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// ZUnionWithScores returns the union of the sorted sets with the aggregated
+// scores.
+// Redis 6.2+
+func (r *Redis) ZUnionWithScores(keys []string, opts ZAggregateOptions) ([]ZMember, error) {
+	args := opts.args(packArgs("ZUNION", len(keys), keys))
+	rp, err := r.ExecuteCommand(append(args, "WITHSCORES")...)
+	if err != nil {
+		return nil, err
+	}
+	return zMembersValue(rp)
+}
+
+// ZUnionStoreWithOptions is ZUnionStore with float weights.
+func (r *Redis) ZUnionStoreWithOptions(destination string, keys []string, opts ZAggregateOptions) (int64, error) {
+	args := opts.args(packArgs("ZUNIONSTORE", destination, len(keys), keys))
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// ZDIFF numkeys key [key ...] [WITHSCORES]
+// ZDiff returns the members of the first sorted set which are not in the
+// others.
+// Redis 6.2+
+func (r *Redis) ZDiff(keys ...string) ([]string, error) {
+	args := packArgs("ZDIFF", len(keys), keys)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.ListValue()
+}
+
+// ZDiffWithScores is ZDiff returning the scores of the members.
+// Redis 6.2+
+func (r *Redis) ZDiffWithScores(keys ...string) ([]ZMember, error) {
+	args := packArgs("ZDIFF", len(keys), keys, "WITHSCORES")
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return zMembersValue(rp)
+}
+
+// ZDIFFSTORE destination numkeys key [key ...]
+// ZDiffStore stores the difference of the sorted sets in destination.
+// Returns the number of members stored.
+// Redis 6.2+
+func (r *Redis) ZDiffStore(destination string, keys ...string) (int64, error) {
+	args := packArgs("ZDIFFSTORE", destination, len(keys), keys)
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// zPopResultValue reads the reply of ZMPOP, the key and its popped members
+func zPopResultValue(rp *Reply) (ZPopResult, error) {
+	multi, err := rp.MultiValue()
+	if err != nil || len(multi) == 0 {
+		return ZPopResult{}, err
+	}
+	if len(multi) != 2 {
+		return ZPopResult{}, errors.New("invalid reply, not a key and its members")
+	}
+	key, err := multi[0].StringValue()
+	if err != nil {
+		return ZPopResult{}, err
+	}
+	members, err := zMembersValue(multi[1])
+	if err != nil {
+		return ZPopResult{}, err
+	}
+	return ZPopResult{Key: key, Members: members}, nil
+}
+
+// ZMPOP numkeys key [key ...] MIN|MAX [COUNT count]
+// ZMPop pops up to count members, at least one, with the lowest or highest
+// scores from the first non-empty sorted set. where is ZMPopMin or ZMPopMax.
+// An empty ZPopResult is returned when all the sets are empty.
+// Redis 7.0+
+func (r *Redis) ZMPop(keys []string, where string, count int) (ZPopResult, error) {
+	args := packArgs("ZMPOP", len(keys), keys, where)
+	if count > 1 {
+		args = append(args, "COUNT", count)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return ZPopResult{}, err
+	}
+	return zPopResultValue(rp)
+}
+
+// BZMPOP timeout numkeys key [key ...] MIN|MAX [COUNT count]
+// BZMPop is the blocking variant of ZMPOP, waiting up to timeout seconds,
+// 0 waits forever.
+// Redis 7.0+
+func (r *Redis) BZMPop(timeout int, keys []string, where string, count int) (ZPopResult, error) {
+	args := packArgs("BZMPOP", timeout, len(keys), keys, where)
+	if count > 1 {
+		args = append(args, "COUNT", count)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return ZPopResult{}, err
+	}
+	return zPopResultValue(rp)
+}
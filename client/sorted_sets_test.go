@@ -1,7 +1,9 @@
 package client
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestZAdd(t *testing.T) {
@@ -432,3 +434,175 @@ func TestZMScore(t *testing.T) {
 		t.Error("Expected 3 scores, got", len(scores))
 	}
 }
+
+func TestZRangeWithOptions(t *testing.T) {
+	replies := []string{
+		// RESP2
+		"*4\r\n$3\r\none\r\n$1\r\n1\r\n$3\r\ntwo\r\n$3\r\n2.5\r\n",
+		// RESP3
+		"*2\r\n*2\r\n$3\r\none\r\n,1\r\n*2\r\n$3\r\ntwo\r\n,2.5\r\n",
+	}
+	for _, reply := range replies {
+		addr, commands := serveReplies(t, reply, "*2\r\n$1\r\na\r\n$1\r\nb\r\n")
+		rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		members, err := rr.ZRangeWithOptions("key", "(0", "+inf", ZRangeOptions{ByScore: true, Offset: 0, Count: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd := strings.Join(<-commands, " "); cmd != "ZRANGE key (0 +inf BYSCORE LIMIT 0 2 WITHSCORES" {
+			t.Errorf("unexpected command %q", cmd)
+		}
+		if len(members) != 2 || members[0] != (ZMember{"one", 1}) || members[1] != (ZMember{"two", 2.5}) {
+			t.Errorf("unexpected members %v", members)
+		}
+		members, err = rr.ZRangeWithOptions("key", "[z", "-", ZRangeOptions{ByLex: true, Rev: true})
+		rr.ClosePool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd := strings.Join(<-commands, " "); cmd != "ZRANGE key [z - BYLEX REV" {
+			t.Errorf("unexpected command %q", cmd)
+		}
+		if len(members) != 2 || members[0].Member != "a" || members[1].Member != "b" {
+			t.Errorf("unexpected members %v", members)
+		}
+	}
+}
+
+func TestZAddWithOptions(t *testing.T) {
+	addr, commands := serveReplies(t, ":2\r\n", "$1\r\n3\r\n", "$-1\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	n, err := rr.ZAddWithOptions("key", []ZMember{{"a", 1}, {"b", 2}}, ZAddOptions{XX: true, GT: true, CH: true})
+	if err != nil || n != 2 {
+		t.Errorf("unexpected result %d, %v", n, err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "ZADD key XX GT CH 1 a 2 b" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	score, err := rr.ZAddIncr("key", "a", 2, ZAddOptions{NX: true})
+	if err != nil || score != 3 {
+		t.Errorf("unexpected result %v, %v", score, err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "ZADD key NX INCR 2 a" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if _, err := rr.ZAddIncr("key", "a", 2, ZAddOptions{NX: true}); err != ErrNil {
+		t.Errorf("expected ErrNil, got %v", err)
+	}
+}
+
+func TestZAggregate(t *testing.T) {
+	addr, commands := serveReplies(t,
+		"*2\r\n$1\r\na\r\n$1\r\n4\r\n",
+		":3\r\n",
+		":1\r\n",
+		"*1\r\n*2\r\n$1\r\nb\r\n,1\r\n",
+	)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	members, err := rr.ZInterWithScores([]string{"k1", "k2"}, ZAggregateOptions{Weights: []float64{1, 1.5}, Aggregate: ZAggregateMax})
+	if err != nil || len(members) != 1 || members[0] != (ZMember{"a", 4}) {
+		t.Errorf("unexpected result %v, %v", members, err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "ZINTER 2 k1 k2 WEIGHTS 1 1.5 AGGREGATE MAX WITHSCORES" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if _, err := rr.ZUnionStoreWithOptions("dst", []string{"k1", "k2"}, ZAggregateOptions{}); err != nil {
+		t.Error(err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "ZUNIONSTORE dst 2 k1 k2" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if n, err := rr.ZInterCard([]string{"k1", "k2"}, 10); err != nil || n != 1 {
+		t.Errorf("unexpected result %d, %v", n, err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "ZINTERCARD 2 k1 k2 LIMIT 10" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	members, err = rr.ZDiffWithScores("k1", "k2")
+	if err != nil || len(members) != 1 || members[0] != (ZMember{"b", 1}) {
+		t.Errorf("unexpected result %v, %v", members, err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "ZDIFF 2 k1 k2 WITHSCORES" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+}
+
+func TestZMPop(t *testing.T) {
+	replies := []string{
+		// RESP2, scores are bulk strings
+		"*2\r\n$2\r\nk2\r\n*2\r\n*2\r\n$1\r\na\r\n$1\r\n1\r\n*2\r\n$1\r\nb\r\n$1\r\n2\r\n",
+		// RESP3
+		"*2\r\n$2\r\nk2\r\n*2\r\n*2\r\n$1\r\na\r\n,1\r\n*2\r\n$1\r\nb\r\n,2\r\n",
+	}
+	for _, reply := range replies {
+		addr, commands := serveReplies(t, reply, "*-1\r\n")
+		rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := rr.ZMPop([]string{"k1", "k2"}, ZMPopMin, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd := strings.Join(<-commands, " "); cmd != "ZMPOP 2 k1 k2 MIN COUNT 2" {
+			t.Errorf("unexpected command %q", cmd)
+		}
+		if res.Key != "k2" || len(res.Members) != 2 || res.Members[0] != (ZMember{"a", 1}) || res.Members[1] != (ZMember{"b", 2}) {
+			t.Errorf("unexpected result %+v", res)
+		}
+		res, err = rr.BZMPop(1, []string{"k1"}, ZMPopMax, 1)
+		rr.ClosePool()
+		if err != nil || res.Key != "" || res.Members != nil {
+			t.Errorf("expected an empty result, got %+v, %v", res, err)
+		}
+		if cmd := strings.Join(<-commands, " "); cmd != "BZMPOP 1 1 k1 MAX" {
+			t.Errorf("unexpected command %q", cmd)
+		}
+	}
+}
+
+func TestSortedSetScoresRESP3(t *testing.T) {
+	addr, _ := serveReplies(t,
+		",3.5\r\n",
+		",2.5\r\n",
+		"*2\r\n,1\r\n_\r\n",
+		"*2\r\n*2\r\n$3\r\none\r\n,1\r\n*2\r\n$3\r\ntwo\r\n,2.5\r\n",
+		"*2\r\n*2\r\n$3\r\none\r\n,1\r\n*2\r\n$3\r\ntwo\r\n,2.5\r\n",
+		"*1\r\n*2\r\n$3\r\none\r\n,1\r\n",
+	)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	if f, err := rr.ZIncrBy("key", 1, "one"); err != nil || f != 3.5 {
+		t.Errorf("unexpected ZIncrBy %v %v", f, err)
+	}
+	if b, err := rr.ZScore("key", "two"); err != nil || string(b) != "2.5" {
+		t.Errorf("unexpected ZScore %q %v", b, err)
+	}
+	if scores, err := rr.ZMScore("key", "one", "none"); err != nil || len(scores) != 2 || scores[0] != 1 || scores[1] != 0 {
+		t.Errorf("unexpected ZMScore %v %v", scores, err)
+	}
+	if list, err := rr.ZRange("key", 0, -1, true); err != nil || strings.Join(list, " ") != "one 1 two 2.5" {
+		t.Errorf("unexpected ZRange %v %v", list, err)
+	}
+	if list, err := rr.ZRevRangeByScore("key", "+inf", "-inf", true, false, 0, 0); err != nil || strings.Join(list, " ") != "one 1 two 2.5" {
+		t.Errorf("unexpected ZRevRangeByScore %v %v", list, err)
+	}
+	members, err := rr.ZRandMemberWithOptions("key", ZRandMemberOptions{Count: 1, WithScores: true})
+	if err != nil || len(members) != 1 || members[0] != (ZMember{"one", 1}) {
+		t.Errorf("unexpected ZRandMember %v %v", members, err)
+	}
+}
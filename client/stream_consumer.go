@@ -0,0 +1,305 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Defaults of StreamConsumerConfig
+const (
+	DefaultStreamConsumerWorkers = 4
+	DefaultStreamConsumerBlock   = 2 * time.Second
+	DefaultStreamClaimInterval   = 30 * time.Second
+	DefaultStreamClaimMinIdle    = time.Minute
+)
+
+// Fields added to the entries moved to a dead-letter stream, next to their
+// own fields
+const (
+	DeadLetterStreamField     = "dead-letter-stream"
+	DeadLetterIDField         = "dead-letter-id"
+	DeadLetterDeliveriesField = "dead-letter-deliveries"
+)
+
+// StreamHandler processes an entry read by a StreamConsumer. The entry is
+// acknowledged when it returns nil, otherwise it stays pending and is
+// delivered again once claimed.
+type StreamHandler func(ctx context.Context, entry StreamEntry) error
+
+// StreamConsumerConfig controls how a StreamConsumer reads a stream
+type StreamConsumerConfig struct {
+	Stream string // stream to read
+	Group  string // consumer group reading the stream
+	// Consumer is the name of the consumer in the group, the host name and
+	// the process ID when empty. Consumers of the group must have distinct
+	// names.
+	Consumer string
+	// CreateGroup creates the group, and the stream, if it does not exist.
+	// A new group reads the entries added from then on.
+	CreateGroup bool
+	// Workers is the number of entries handled at once,
+	// DefaultStreamConsumerWorkers when 0
+	Workers int
+	// Block is how long a read waits for new entries,
+	// DefaultStreamConsumerBlock when 0
+	Block time.Duration
+	// ClaimInterval is how often the pending entries idle for ClaimMinIdle
+	// are claimed with XAUTOCLAIM, whichever consumer they were delivered
+	// to. They default to DefaultStreamClaimInterval and
+	// DefaultStreamClaimMinIdle.
+	ClaimInterval time.Duration
+	ClaimMinIdle  time.Duration
+	// MaxDeliveries moves a claimed entry delivered more than MaxDeliveries
+	// times to DeadLetterStream instead of handling it again, 0 never does
+	MaxDeliveries int64
+	// DeadLetterStream receives the fields of dead-lettered entries with
+	// the DeadLetter fields added, Stream + ":dead" when empty
+	DeadLetterStream string
+	// OnError, when set, is called with the errors of the handler and of
+	// Redis. Calls may be concurrent.
+	OnError func(error)
+}
+
+// StreamConsumer reads a stream as a member of a consumer group and hands
+// the entries to a StreamHandler from several goroutines, hiding the
+// XREADGROUP, XACK and XAUTOCLAIM loop:
+//
+//	c := client.NewStreamConsumer(r, &client.StreamConsumerConfig{
+//		Stream:        "orders",
+//		Group:         "billing",
+//		MaxDeliveries: 5,
+//	}, func(ctx context.Context, entry client.StreamEntry) error {
+//		return bill(ctx, entry.Fields)
+//	})
+//	err := c.Run(ctx)
+//
+// Delivery is at least once: an entry whose handler failed, or which was
+// read by a consumer which stopped, is handled again after ClaimMinIdle.
+type StreamConsumer struct {
+	r       *Redis
+	cfg     StreamConsumerConfig
+	handler StreamHandler
+}
+
+// NewStreamConsumer returns a StreamConsumer calling handler for the entries
+// of cfg.Stream
+func NewStreamConsumer(r *Redis, cfg *StreamConsumerConfig, handler StreamHandler) *StreamConsumer {
+	c := &StreamConsumer{r: r, cfg: *cfg, handler: handler}
+	if c.cfg.Consumer == "" {
+		host, _ := os.Hostname()
+		c.cfg.Consumer = host + "-" + strconv.Itoa(os.Getpid())
+	}
+	if c.cfg.Workers <= 0 {
+		c.cfg.Workers = DefaultStreamConsumerWorkers
+	}
+	// BLOCK 0 would wait forever
+	if c.cfg.Block < time.Millisecond {
+		c.cfg.Block = DefaultStreamConsumerBlock
+	}
+	if c.cfg.ClaimInterval <= 0 {
+		c.cfg.ClaimInterval = DefaultStreamClaimInterval
+	}
+	if c.cfg.ClaimMinIdle <= 0 {
+		c.cfg.ClaimMinIdle = DefaultStreamClaimMinIdle
+	}
+	if c.cfg.DeadLetterStream == "" {
+		c.cfg.DeadLetterStream = c.cfg.Stream + ":dead"
+	}
+	return c
+}
+
+// Consumer returns the name of the consumer in the group
+func (c *StreamConsumer) Consumer() string {
+	return c.cfg.Consumer
+}
+
+// Run reads and handles entries until ctx is done. It then stops reading,
+// waits for the handlers in progress, which see ctx done, and returns nil.
+// Entries read but not handled yet stay pending until claimed. Redis errors
+// are reported to OnError and retried, except the deletion of the group
+// which stops Run with ErrNoGroup.
+func (c *StreamConsumer) Run(ctx context.Context) error {
+	if c.cfg.Stream == "" || c.cfg.Group == "" {
+		return errors.New("stream consumer needs a stream and a group")
+	}
+	if c.cfg.CreateGroup {
+		err := c.r.WithContext(ctx).XGroupCreateWithOptions(c.cfg.Stream, c.cfg.Group, StreamIDLatest, XGroupCreateOptions{MkStream: true})
+		if err != nil && !errors.Is(err, ErrBusyGroup) {
+			return err
+		}
+	}
+
+	entries := make(chan StreamEntry)
+	var wg sync.WaitGroup
+	for i := 0; i < c.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range entries {
+				c.handle(ctx, entry)
+			}
+		}()
+	}
+	err := c.read(ctx, entries)
+	close(entries)
+	wg.Wait()
+	return err
+}
+
+// read feeds entries with new entries, and with the claimed ones every
+// ClaimInterval, until ctx is done
+func (c *StreamConsumer) read(ctx context.Context, entries chan<- StreamEntry) error {
+	r := c.r.WithContext(ctx)
+	// claim first the entries left pending by consumers which stopped
+	nextClaim := time.Now()
+	claimStart := StreamIDEarliest
+	var delay time.Duration
+	for ctx.Err() == nil {
+		var batch []StreamEntry
+		var err error
+		if time.Now().Before(nextClaim) {
+			batch, err = c.readNew(ctx)
+		} else {
+			var next string
+			if batch, next, err = c.claim(r, claimStart); err == nil {
+				// scan the whole PEL before waiting for the next interval
+				if claimStart = next; next == StreamIDEarliest {
+					nextClaim = time.Now().Add(c.cfg.ClaimInterval)
+				}
+			}
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			if errors.Is(err, ErrNoGroup) {
+				return err
+			}
+			c.report(err)
+			if delay = 2 * delay; delay == 0 {
+				delay = 10 * time.Millisecond
+			} else if delay > c.cfg.Block {
+				delay = c.cfg.Block
+			}
+			sleepContext(ctx, delay)
+			continue
+		}
+		delay = 0
+		for _, entry := range batch {
+			select {
+			case entries <- entry:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// readNew reads up to Workers entries never delivered to the group
+func (c *StreamConsumer) readNew(ctx context.Context) ([]StreamEntry, error) {
+	// the socket deadline must outlast the BLOCK
+	readCtx, cancel := context.WithTimeout(ctx, c.cfg.Block+c.r.timeout)
+	defer cancel()
+	messages, err := c.r.WithContext(readCtx).XReadGroupWithOptions(c.cfg.Group, c.cfg.Consumer,
+		map[string]string{c.cfg.Stream: ">"},
+		XReadGroupOptions{Count: int64(c.cfg.Workers), Block: int64(c.cfg.Block / time.Millisecond)})
+	if err != nil {
+		return nil, err
+	}
+	var batch []StreamEntry
+	for _, m := range messages {
+		batch = append(batch, m.Entries...)
+	}
+	return batch, nil
+}
+
+// claim claims up to Workers idle pending entries from start, returning
+// those to handle and the start of the next claim. The entries delivered
+// more than MaxDeliveries times are dead-lettered, and those deleted from
+// the stream acknowledged.
+func (c *StreamConsumer) claim(r *Redis, start string) ([]StreamEntry, string, error) {
+	res, err := r.XAutoClaim(c.cfg.Stream, c.cfg.Group, c.cfg.Consumer,
+		int64(c.cfg.ClaimMinIdle/time.Millisecond), start, XAutoClaimOptions{Count: int64(c.cfg.Workers)})
+	if err != nil {
+		return nil, start, err
+	}
+	// Redis 6.2 leaves the deleted entries pending
+	if len(res.Deleted) > 0 {
+		if _, err := r.XAck(c.cfg.Stream, c.cfg.Group, res.Deleted...); err != nil {
+			return nil, start, err
+		}
+	}
+	if c.cfg.MaxDeliveries <= 0 || len(res.Entries) == 0 {
+		return res.Entries, res.Next, nil
+	}
+
+	// XAUTOCLAIM does not return the delivery counts
+	pipe := r.Pipeline()
+	pending := make([]*Cmd[[]XPendingMessage], len(res.Entries))
+	for i, entry := range res.Entries {
+		pending[i] = pipe.XPendingWithOptions(c.cfg.Stream, c.cfg.Group, XPendingOptions{
+			Start: entry.ID, End: entry.ID, Count: 1, Consumer: c.cfg.Consumer,
+		})
+	}
+	if err := pipe.Exec(); err != nil {
+		return nil, start, err
+	}
+	batch := res.Entries[:0]
+	for i, entry := range res.Entries {
+		messages, err := pending[i].Result()
+		if err != nil {
+			return nil, start, err
+		}
+		if len(messages) == 0 || messages[0].DeliveryCount <= c.cfg.MaxDeliveries {
+			batch = append(batch, entry)
+			continue
+		}
+		if err := c.deadLetter(r, entry, messages[0].DeliveryCount); err != nil {
+			c.report(err)
+		}
+	}
+	return batch, res.Next, nil
+}
+
+// deadLetter moves entry to the dead-letter stream
+func (c *StreamConsumer) deadLetter(r *Redis, entry StreamEntry, deliveries int64) error {
+	fields := make(map[string]string, len(entry.Fields)+3)
+	for field, value := range entry.Fields {
+		fields[field] = value
+	}
+	fields[DeadLetterStreamField] = c.cfg.Stream
+	fields[DeadLetterIDField] = entry.ID
+	fields[DeadLetterDeliveriesField] = strconv.FormatInt(deliveries, 10)
+	if _, err := r.XAdd(c.cfg.DeadLetterStream, StreamIDAutoGenerate, fields); err != nil {
+		return fmt.Errorf("dead-letter entry %s: %w", entry.ID, err)
+	}
+	if _, err := r.XAck(c.cfg.Stream, c.cfg.Group, entry.ID); err != nil {
+		return fmt.Errorf("acknowledge dead-lettered entry %s: %w", entry.ID, err)
+	}
+	return nil
+}
+
+// handle calls the handler and acknowledges entry on success
+func (c *StreamConsumer) handle(ctx context.Context, entry StreamEntry) {
+	if err := c.handler(ctx, entry); err != nil {
+		c.report(fmt.Errorf("handle entry %s: %w", entry.ID, err))
+		return
+	}
+	// the entry was handled, acknowledge it even when shutting down
+	r := c.r.WithContext(context.WithoutCancel(ctx))
+	if _, err := r.XAck(c.cfg.Stream, c.cfg.Group, entry.ID); err != nil {
+		c.report(fmt.Errorf("acknowledge entry %s: %w", entry.ID, err))
+	}
+}
+
+func (c *StreamConsumer) report(err error) {
+	if c.cfg.OnError != nil {
+		c.cfg.OnError(err)
+	}
+}
@@ -0,0 +1,271 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStream serves one stream and its group on any number of connections
+type fakeStream struct {
+	mu        sync.Mutex
+	entries   []StreamEntry
+	delivered int // entries read with >
+	pending   map[string]*XPendingMessage
+	acked     []string
+	dead      []map[string]string
+	deleted   map[string]bool // pending entries removed from the stream
+}
+
+func serveStream(t *testing.T, entries ...StreamEntry) (*fakeStream, *Redis) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	s := &fakeStream{entries: entries, pending: make(map[string]*XPendingMessage), deleted: make(map[string]bool)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				c := &connection{Conn: conn, Reader: bufio.NewReader(conn)}
+				for {
+					rp, err := c.RecvReply()
+					if err != nil {
+						return
+					}
+					args, _ := rp.ListValue()
+					if _, err := conn.Write([]byte(s.reply(args))); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	rr, err := DialWithConfig(&DialConfig{Address: ln.Addr().String(), Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(rr.ClosePool)
+	return s, rr
+}
+
+func bulk(s string) string {
+	return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n"
+}
+
+func entryReply(e StreamEntry) string {
+	reply := "*2\r\n" + bulk(e.ID) + "*" + strconv.Itoa(2*len(e.Fields)) + "\r\n"
+	for field, value := range e.Fields {
+		reply += bulk(field) + bulk(value)
+	}
+	return reply
+}
+
+func (s *fakeStream) reply(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch args[0] {
+	case "XREADGROUP":
+		consumer := args[3]
+		if s.delivered == len(s.entries) {
+			s.mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			s.mu.Lock()
+			return "*-1\r\n"
+		}
+		e := s.entries[s.delivered]
+		s.delivered++
+		s.pending[e.ID] = &XPendingMessage{ID: e.ID, Consumer: consumer, DeliveryCount: 1}
+		return "*1\r\n*2\r\n" + bulk(args[len(args)-2]) + "*1\r\n" + entryReply(e)
+	case "XAUTOCLAIM":
+		consumer := args[3]
+		var claimed, deleted []string
+		for _, e := range s.entries {
+			if p, ok := s.pending[e.ID]; ok && s.deleted[e.ID] {
+				deleted = append(deleted, bulk(e.ID))
+			} else if ok {
+				p.Consumer = consumer
+				p.DeliveryCount++
+				claimed = append(claimed, entryReply(e))
+			}
+		}
+		return "*3\r\n" + bulk("0-0") + "*" + strconv.Itoa(len(claimed)) + "\r\n" + strings.Join(claimed, "") +
+			"*" + strconv.Itoa(len(deleted)) + "\r\n" + strings.Join(deleted, "")
+	case "XPENDING":
+		p, ok := s.pending[args[3]]
+		if !ok {
+			return "*0\r\n"
+		}
+		return "*1\r\n*4\r\n" + bulk(p.ID) + bulk(p.Consumer) + ":0\r\n:" + strconv.FormatInt(p.DeliveryCount, 10) + "\r\n"
+	case "XACK":
+		for _, id := range args[3:] {
+			delete(s.pending, id)
+			s.acked = append(s.acked, id)
+		}
+		return ":" + strconv.Itoa(len(args)-3) + "\r\n"
+	case "XADD":
+		fields := make(map[string]string)
+		for i := 3; i+1 < len(args); i += 2 {
+			fields[args[i]] = args[i+1]
+		}
+		s.dead = append(s.dead, fields)
+		return bulk("1-0")
+	}
+	return "+OK\r\n"
+}
+
+func (s *fakeStream) snapshot() ([]string, []map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.acked...), append([]map[string]string(nil), s.dead...)
+}
+
+func TestStreamConsumerDeadLetter(t *testing.T) {
+	s, rr := serveStream(t,
+		StreamEntry{ID: "1-0", Fields: map[string]string{"order": "1"}},
+		StreamEntry{ID: "2-0", Fields: map[string]string{"order": "2"}},
+	)
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	var errs []error
+	c := NewStreamConsumer(rr, &StreamConsumerConfig{
+		Stream:        "orders",
+		Group:         "billing",
+		Consumer:      "worker",
+		CreateGroup:   true,
+		Block:         10 * time.Millisecond,
+		ClaimInterval: 20 * time.Millisecond,
+		ClaimMinIdle:  time.Millisecond,
+		MaxDeliveries: 2,
+		OnError: func(err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		},
+	}, func(ctx context.Context, entry StreamEntry) error {
+		mu.Lock()
+		calls[entry.ID]++
+		mu.Unlock()
+		if entry.Fields["order"] == "2" {
+			return errors.New("poison")
+		}
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, dead := s.snapshot(); len(dead) > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	acked, dead := s.snapshot()
+	if len(dead) != 1 {
+		t.Fatalf("expected one dead-lettered entry, got %v", dead)
+	}
+	if d := dead[0]; d["order"] != "2" || d[DeadLetterIDField] != "2-0" || d[DeadLetterStreamField] != "orders" || d[DeadLetterDeliveriesField] != "3" {
+		t.Errorf("unexpected dead-lettered entry %v", d)
+	}
+	if strings.Join(acked, ",") != "1-0,2-0" {
+		t.Errorf("unexpected acknowledged entries %v", acked)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls["1-0"] != 1 || calls["2-0"] != 2 {
+		t.Errorf("unexpected handler calls %v", calls)
+	}
+	if len(errs) != 2 || !strings.Contains(fmt.Sprint(errs[0]), "poison") {
+		t.Errorf("unexpected errors %v", errs)
+	}
+}
+
+func TestStreamConsumerAcksDeleted(t *testing.T) {
+	s, rr := serveStream(t, StreamEntry{ID: "1-0", Fields: map[string]string{"order": "1"}})
+	handled := make(chan struct{}, 1)
+	c := NewStreamConsumer(rr, &StreamConsumerConfig{
+		Stream:        "orders",
+		Group:         "billing",
+		Block:         10 * time.Millisecond,
+		ClaimInterval: 20 * time.Millisecond,
+		ClaimMinIdle:  time.Millisecond,
+	}, func(ctx context.Context, entry StreamEntry) error {
+		// deleted from the stream while it was handled
+		s.mu.Lock()
+		s.deleted[entry.ID] = true
+		s.mu.Unlock()
+		select {
+		case handled <- struct{}{}:
+		default:
+		}
+		return errors.New("failed")
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+	<-handled
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if acked, _ := s.snapshot(); len(acked) > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if acked, _ := s.snapshot(); strings.Join(acked, ",") != "1-0" {
+		t.Errorf("expected the deleted entry to be acknowledged, got %v", acked)
+	}
+}
+
+func TestStreamConsumerShutdown(t *testing.T) {
+	s, rr := serveStream(t, StreamEntry{ID: "1-0", Fields: map[string]string{"order": "1"}})
+	started := make(chan struct{})
+	c := NewStreamConsumer(rr, &StreamConsumerConfig{
+		Stream: "orders",
+		Group:  "billing",
+		Block:  10 * time.Millisecond,
+	}, func(ctx context.Context, entry StreamEntry) error {
+		close(started)
+		<-ctx.Done()
+		// finish the work in progress
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	if c.Consumer() == "" {
+		t.Error("expected a default consumer name")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+	<-started
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if acked, _ := s.snapshot(); len(acked) != 1 {
+		t.Errorf("expected the entry in progress to be acknowledged, got %v", acked)
+	}
+}
@@ -1,5 +1,10 @@
 package client
 
+import (
+	"errors"
+	"time"
+)
+
 // Stream constants and types
 const (
 	StreamIDAutoGenerate = "*"
@@ -71,6 +76,19 @@ type XPendingOptions struct {
 	Consumer string // Specific consumer
 }
 
+// XAutoClaimOptions represents options for XAUTOCLAIM command
+type XAutoClaimOptions struct {
+	Count  int64 // COUNT option
+	JustID bool  // JUSTID option
+}
+
+// XAutoClaimResult represents the reply of XAUTOCLAIM
+type XAutoClaimResult struct {
+	Next    string        // start ID of the next call, "0-0" once the whole PEL was scanned
+	Entries []StreamEntry // claimed entries, without Fields with JustID
+	Deleted []string      // pending IDs no longer in the stream, removed from the PEL by Redis 7.0+ only
+}
+
 // XPendingInfo represents summary of pending messages
 type XPendingInfo struct {
 	Count     int64
@@ -87,6 +105,67 @@ type XPendingMessage struct {
 	DeliveryCount int64
 }
 
+// StreamInfo represents the reply of XINFO STREAM
+type StreamInfo struct {
+	Length               int64
+	RadixTreeKeys        int64
+	RadixTreeNodes       int64
+	LastGeneratedID      string
+	MaxDeletedEntryID    string // Redis 7.0+
+	EntriesAdded         int64  // entries ever added, -1 before Redis 7.0
+	RecordedFirstEntryID string // Redis 7.0+
+	Groups               int64
+	FirstEntry           *StreamEntry // nil when the stream is empty
+	LastEntry            *StreamEntry // nil when the stream is empty
+}
+
+// StreamInfoFull represents the reply of XINFO STREAM FULL. Groups is the
+// number of groups in GroupList, and FirstEntry and LastEntry point into
+// Entries. LastEntry is nil unless Entries holds the whole stream, which the
+// count of XInfoStreamFull may cap.
+type StreamInfoFull struct {
+	StreamInfo
+	Entries   []StreamEntry
+	GroupList []StreamGroupInfo
+}
+
+// StreamGroupInfo represents a consumer group in XINFO GROUPS and XINFO
+// STREAM FULL
+type StreamGroupInfo struct {
+	Name            string
+	Consumers       int64
+	Pending         int64 // entries delivered and not acknowledged
+	LastDeliveredID string
+	EntriesRead     int64 // -1 when unknown. Redis 7.0+
+	Lag             int64 // entries not delivered yet, -1 when unknown. Redis 7.0+
+	// PEL and ConsumerList are only set by XInfoStreamFull
+	PEL          []StreamPendingEntry
+	ConsumerList []StreamConsumerInfo
+}
+
+// StreamConsumerInfo represents a consumer in XINFO CONSUMERS and XINFO
+// STREAM FULL. Redis returns durations to the former and times to the
+// latter, both are set from either.
+type StreamConsumerInfo struct {
+	Name       string
+	Pending    int64         // entries delivered and not acknowledged
+	Idle       time.Duration // since the last attempted interaction
+	SeenTime   time.Time     // time of the last attempted interaction
+	Inactive   time.Duration // since the last successful interaction, -1 if none. Redis 7.2+
+	ActiveTime time.Time     // time of the last successful interaction, zero if none. Redis 7.2+
+	// PEL is only set by XInfoStreamFull
+	PEL []StreamPendingEntry
+}
+
+// StreamPendingEntry represents an entry of the pending entries list of a
+// group or consumer in XINFO STREAM FULL
+type StreamPendingEntry struct {
+	ID            string
+	Consumer      string // empty in the list of a consumer
+	DeliveryTime  time.Time
+	DeliveryCount int64
+}
+
 // Basic Stream Operations
 
 // XADD key [NOMKSTREAM] [MAXLEN|MINID [=|~] threshold [LIMIT count]] *|ID field value [field value ...]
@@ -516,6 +595,125 @@ func (r *Redis) XClaimWithOptions(key, group, consumer string, minIdleTime int64
 	return parseStreamEntries(rp.Multi)
 }
 
+// XAUTOCLAIM key group consumer min-idle-time start [COUNT count] [JUSTID]
+// XAutoClaim transfers ownership of the pending messages idle for at least
+// minIdleTime milliseconds to consumer, scanning the PEL from start. Redis 6.2
+// replies nil for the claimed entries deleted from the stream and leaves them
+// pending, their IDs are then looked up with XPENDING and XRANGE and
+// reported in Deleted.
+// Redis 6.2+
+func (r *Redis) XAutoClaim(key, group, consumer string, minIdleTime int64, start string, opts XAutoClaimOptions) (XAutoClaimResult, error) {
+	args := []interface{}{"XAUTOCLAIM", key, group, consumer, minIdleTime, start}
+	if opts.Count > 0 {
+		args = append(args, "COUNT", opts.Count)
+	}
+	if opts.JustID {
+		args = append(args, "JUSTID")
+	}
+
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return XAutoClaimResult{}, err
+	}
+	if len(rp.Multi) < 2 {
+		return XAutoClaimResult{}, errors.New("invalid reply, not an XAUTOCLAIM reply")
+	}
+
+	var res XAutoClaimResult
+	if res.Next, err = rp.Multi[0].StringValue(); err != nil {
+		return XAutoClaimResult{}, err
+	}
+	res.Entries = make([]StreamEntry, 0, len(rp.Multi[1].Multi))
+	nils := 0
+	for _, entryReply := range rp.Multi[1].Multi {
+		if opts.JustID {
+			id, err := entryReply.StringValue()
+			if err != nil {
+				return XAutoClaimResult{}, err
+			}
+			res.Entries = append(res.Entries, StreamEntry{ID: id, Fields: map[string]string{}})
+			continue
+		}
+		// Redis 6.2 replies nil, without the ID, for the entries deleted
+		// from the stream
+		if entryReply.IsNull() {
+			nils++
+			continue
+		}
+		if len(entryReply.Multi) < 2 {
+			return XAutoClaimResult{}, errors.New("invalid reply, not a stream entry")
+		}
+		entries, err := parseStreamEntries([]*Reply{entryReply})
+		if err != nil {
+			return XAutoClaimResult{}, err
+		}
+		res.Entries = append(res.Entries, entries...)
+	}
+	if len(rp.Multi) > 2 {
+		if res.Deleted, err = rp.Multi[2].ListValue(); err != nil {
+			return XAutoClaimResult{}, err
+		}
+	}
+	if nils > 0 {
+		deleted, err := r.xAutoClaimDeleted(key, group, consumer, start, res, nils)
+		if err != nil {
+			return XAutoClaimResult{}, err
+		}
+		res.Deleted = append(res.Deleted, deleted...)
+	}
+	return res, nil
+}
+
+// xAutoClaimDeleted looks up the IDs of the n entries XAUTOCLAIM replied
+// nil for, pending entries of consumer in the claimed range which are no
+// longer in the stream
+func (r *Redis) xAutoClaimDeleted(key, group, consumer, start string, res XAutoClaimResult, n int) ([]string, error) {
+	end := "+"
+	if res.Next != StreamIDEarliest {
+		end = "(" + res.Next
+	}
+	claimed := make(map[string]bool, len(res.Entries))
+	for _, entry := range res.Entries {
+		claimed[entry.ID] = true
+	}
+	count := int64(len(res.Entries) + n)
+	var deleted []string
+	for len(deleted) < n {
+		pending, err := r.XPendingWithOptions(key, group, XPendingOptions{Start: start, End: end, Count: count, Consumer: consumer})
+		if err != nil {
+			return nil, err
+		}
+		pipe := r.Pipeline()
+		var ids []string
+		var ranges []*StreamEntriesCmd
+		for _, p := range pending {
+			if !claimed[p.ID] {
+				ids = append(ids, p.ID)
+				ranges = append(ranges, pipe.XRange(key, p.ID, p.ID))
+			}
+		}
+		if len(ranges) > 0 {
+			if err := pipe.Exec(); err != nil {
+				return nil, err
+			}
+		}
+		for i, cmd := range ranges {
+			entries, err := cmd.Result()
+			if err != nil {
+				return nil, err
+			}
+			if len(entries) == 0 {
+				deleted = append(deleted, ids[i])
+			}
+		}
+		if int64(len(pending)) < count {
+			break
+		}
+		start = "(" + pending[len(pending)-1].ID
+	}
+	return deleted, nil
+}
+
 // XPENDING key group [[IDLE min-idle-time] start end count [consumer]]
 // XPending returns information about pending messages.
 func (r *Redis) XPending(key, group string) (XPendingInfo, error) {
@@ -595,27 +793,19 @@ func (r *Redis) XPendingWithOptions(key, group string, opts XPendingOptions) ([]
 
 // XINFO STREAM key [FULL [COUNT count]]
 // XInfoStream returns general information about a stream.
-func (r *Redis) XInfoStream(key string) (map[string]interface{}, error) {
+func (r *Redis) XInfoStream(key string) (StreamInfo, error) {
 	args := packArgs("XINFO", "STREAM", key)
 	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
-		return nil, err
+		return StreamInfo{}, err
 	}
-
-	result := make(map[string]interface{})
-	if rp.Multi != nil {
-		for i := 0; i < len(rp.Multi)-1; i += 2 {
-			key, _ := rp.Multi[i].StringValue()
-			value := parseInfoValue(rp.Multi[i+1])
-			result[key] = value
-		}
-	}
-
-	return result, nil
+	return parseStreamInfo(rp)
 }
 
-// XInfoStreamFull returns detailed information about a stream.
-func (r *Redis) XInfoStreamFull(key string, count int64) (map[string]interface{}, error) {
+// XInfoStreamFull returns detailed information about a stream, with its
+// entries and the pending entries of its groups and consumers, up to count
+// of each or 10 when count is 0.
+func (r *Redis) XInfoStreamFull(key string, count int64) (StreamInfoFull, error) {
 	args := []interface{}{"XINFO", "STREAM", key, "FULL"}
 	if count > 0 {
 		args = append(args, "COUNT", count)
@@ -623,74 +813,64 @@ func (r *Redis) XInfoStreamFull(key string, count int64) (map[string]interface{}
 
 	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
-		return nil, err
-	}
-
-	result := make(map[string]interface{})
-	if rp.Multi != nil {
-		for i := 0; i < len(rp.Multi)-1; i += 2 {
-			key, _ := rp.Multi[i].StringValue()
-			value := parseInfoValue(rp.Multi[i+1])
-			result[key] = value
-		}
+		return StreamInfoFull{}, err
 	}
-
-	return result, nil
+	return parseStreamInfoFull(rp)
 }
 
 // XINFO GROUPS key
 // XInfoGroups returns information about consumer groups.
-func (r *Redis) XInfoGroups(key string) ([]map[string]interface{}, error) {
+func (r *Redis) XInfoGroups(key string) ([]StreamGroupInfo, error) {
 	args := packArgs("XINFO", "GROUPS", key)
 	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]map[string]interface{}, len(rp.Multi))
+	groups := make([]StreamGroupInfo, len(rp.Multi))
 	for i, groupReply := range rp.Multi {
-		group := make(map[string]interface{})
-		if groupReply.Multi != nil {
-			for j := 0; j < len(groupReply.Multi)-1; j += 2 {
-				key, _ := groupReply.Multi[j].StringValue()
-				value := parseInfoValue(groupReply.Multi[j+1])
-				group[key] = value
-			}
+		if groups[i], err = parseStreamGroupInfo(groupReply); err != nil {
+			return nil, err
 		}
-		result[i] = group
 	}
-
-	return result, nil
+	return groups, nil
 }
 
 // XINFO CONSUMERS key groupname
 // XInfoConsumers returns information about consumers in a group.
-func (r *Redis) XInfoConsumers(key, groupname string) ([]map[string]interface{}, error) {
+func (r *Redis) XInfoConsumers(key, groupname string) ([]StreamConsumerInfo, error) {
 	args := packArgs("XINFO", "CONSUMERS", key, groupname)
 	rp, err := r.ExecuteCommand(args...)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]map[string]interface{}, len(rp.Multi))
+	now := time.Now()
+	consumers := make([]StreamConsumerInfo, len(rp.Multi))
 	for i, consumerReply := range rp.Multi {
-		consumer := make(map[string]interface{})
-		if consumerReply.Multi != nil {
-			for j := 0; j < len(consumerReply.Multi)-1; j += 2 {
-				key, _ := consumerReply.Multi[j].StringValue()
-				value := parseInfoValue(consumerReply.Multi[j+1])
-				consumer[key] = value
-			}
+		if consumers[i], err = parseStreamConsumerInfo(consumerReply, now); err != nil {
+			return nil, err
 		}
-		result[i] = consumer
 	}
-
-	return result, nil
+	return consumers, nil
 }
 
 // Helper functions
 
 func parseStreamMessages(replies []*Reply) ([]StreamMessage, error) {
+	if len(replies) > 0 && !replies[0].isAggregate() {
+		// RESP3 replies with a map of stream names to entries
+		messages := make([]StreamMessage, 0, len(replies)/2)
+		for i := 0; i+1 < len(replies); i += 2 {
+			streamName, _ := replies[i].StringValue()
+			entries, _ := parseStreamEntries(replies[i+1].Multi)
+			messages = append(messages, StreamMessage{
+				Stream:  streamName,
+				Entries: entries,
+			})
+		}
+		return messages, nil
+	}
 	messages := make([]StreamMessage, len(replies))
 	for i, streamReply := range replies {
 		if len(streamReply.Multi) >= 2 {
@@ -730,19 +910,236 @@ func parseStreamEntries(replies []*Reply) ([]StreamEntry, error) {
 	return entries, nil
 }
 
-func parseInfoValue(reply *Reply) interface{} {
-	if reply.Type == IntegerReply {
-		return reply.Integer
+// infoInt reads an integer field of XINFO, -1 when null
+func infoInt(rp *Reply) (int64, error) {
+	if rp.IsNull() {
+		return -1, nil
+	}
+	return rp.IntegerValue()
+}
+
+// infoString reads a string field of XINFO, empty when null
+func infoString(rp *Reply) (string, error) {
+	if rp.IsNull() {
+		return "", nil
+	}
+	return rp.StringValue()
+}
+
+// infoEntry reads the first or last entry of XINFO STREAM
+func infoEntry(rp *Reply) (*StreamEntry, error) {
+	if rp.IsNull() {
+		return nil, nil
 	}
-	if reply.Type == BulkReply {
-		return string(reply.Bulk)
+	entries, err := parseStreamEntries([]*Reply{rp})
+	if err != nil {
+		return nil, err
 	}
-	if reply.Type == MultiReply && reply.Multi != nil {
-		result := make([]interface{}, len(reply.Multi))
-		for i, r := range reply.Multi {
-			result[i] = parseInfoValue(r)
+	return &entries[0], nil
+}
+
+// parseStreamInfoField reads the fields XINFO STREAM has in both forms,
+// returning false for the other fields
+func parseStreamInfoField(info *StreamInfo, field string, value *Reply) (ok bool, err error) {
+	switch field {
+	case "length":
+		info.Length, err = value.IntegerValue()
+	case "radix-tree-keys":
+		info.RadixTreeKeys, err = value.IntegerValue()
+	case "radix-tree-nodes":
+		info.RadixTreeNodes, err = value.IntegerValue()
+	case "last-generated-id":
+		info.LastGeneratedID, err = infoString(value)
+	case "max-deleted-entry-id":
+		info.MaxDeletedEntryID, err = infoString(value)
+	case "entries-added":
+		info.EntriesAdded, err = infoInt(value)
+	case "recorded-first-entry-id":
+		info.RecordedFirstEntryID, err = infoString(value)
+	default:
+		return false, nil
+	}
+	return true, err
+}
+
+func parseStreamInfo(rp *Reply) (StreamInfo, error) {
+	var info StreamInfo
+	err := eachField(rp, func(field string, value *Reply) error {
+		if ok, err := parseStreamInfoField(&info, field, value); ok {
+			return err
+		}
+		var err error
+		switch field {
+		case "groups":
+			info.Groups, err = value.IntegerValue()
+		case "first-entry":
+			info.FirstEntry, err = infoEntry(value)
+		case "last-entry":
+			info.LastEntry, err = infoEntry(value)
+		}
+		return err
+	})
+	return info, err
+}
+
+func parseStreamInfoFull(rp *Reply) (StreamInfoFull, error) {
+	var info StreamInfoFull
+	now := time.Now()
+	err := eachField(rp, func(field string, value *Reply) error {
+		if ok, err := parseStreamInfoField(&info.StreamInfo, field, value); ok {
+			return err
 		}
-		return result
+		switch field {
+		case "entries":
+			entries, err := parseStreamEntries(value.Multi)
+			if err != nil {
+				return err
+			}
+			info.Entries = entries
+			if len(entries) > 0 {
+				info.FirstEntry = &info.Entries[0]
+			}
+		case "groups":
+			info.GroupList = make([]StreamGroupInfo, len(value.Multi))
+			for i, groupReply := range value.Multi {
+				group, err := parseStreamGroupInfo(groupReply)
+				if err != nil {
+					return err
+				}
+				for j := range group.ConsumerList {
+					c := &group.ConsumerList[j]
+					c.Idle = now.Sub(c.SeenTime)
+					if !c.ActiveTime.IsZero() {
+						c.Inactive = now.Sub(c.ActiveTime)
+					}
+				}
+				info.GroupList[i] = group
+			}
+			info.Groups = int64(len(value.Multi))
+		}
+		return nil
+	})
+	// Entries holds the whole stream only when COUNT did not cap it
+	if n := len(info.Entries); n > 0 && int64(n) == info.Length {
+		info.LastEntry = &info.Entries[n-1]
 	}
-	return nil
+	return info, err
+}
+
+// parseStreamGroupInfo reads a group of XINFO GROUPS, or of the full form
+// of XINFO STREAM which lists the pending entries and the consumers
+func parseStreamGroupInfo(rp *Reply) (StreamGroupInfo, error) {
+	group := StreamGroupInfo{EntriesRead: -1, Lag: -1}
+	err := eachField(rp, func(field string, value *Reply) (err error) {
+		switch field {
+		case "name":
+			group.Name, err = value.StringValue()
+		case "last-delivered-id":
+			group.LastDeliveredID, err = infoString(value)
+		case "entries-read":
+			group.EntriesRead, err = infoInt(value)
+		case "lag":
+			group.Lag, err = infoInt(value)
+		case "pel-count":
+			group.Pending, err = value.IntegerValue()
+		case "pending":
+			if !value.isAggregate() {
+				group.Pending, err = value.IntegerValue()
+				break
+			}
+			group.PEL, err = parseStreamPendingEntries(value, true)
+		case "consumers":
+			if !value.isAggregate() {
+				group.Consumers, err = value.IntegerValue()
+				break
+			}
+			group.Consumers = int64(len(value.Multi))
+			group.ConsumerList = make([]StreamConsumerInfo, len(value.Multi))
+			for i, consumerReply := range value.Multi {
+				if group.ConsumerList[i], err = parseStreamConsumerInfo(consumerReply, time.Time{}); err != nil {
+					return err
+				}
+			}
+		}
+		return err
+	})
+	return group, err
+}
+
+// parseStreamConsumerInfo reads a consumer of XINFO CONSUMERS, or of the
+// full form of XINFO STREAM which has times rather than durations, the
+// durations of the former are converted to times as of now
+func parseStreamConsumerInfo(rp *Reply, now time.Time) (StreamConsumerInfo, error) {
+	consumer := StreamConsumerInfo{Inactive: -1}
+	err := eachField(rp, func(field string, value *Reply) (err error) {
+		var ms int64
+		switch field {
+		case "name":
+			consumer.Name, err = value.StringValue()
+		case "pel-count":
+			consumer.Pending, err = value.IntegerValue()
+		case "pending":
+			if !value.isAggregate() {
+				consumer.Pending, err = value.IntegerValue()
+				break
+			}
+			consumer.PEL, err = parseStreamPendingEntries(value, false)
+		case "idle":
+			if ms, err = value.IntegerValue(); err == nil {
+				consumer.Idle = time.Duration(ms) * time.Millisecond
+				consumer.SeenTime = now.Add(-consumer.Idle)
+			}
+		case "inactive":
+			if ms, err = value.IntegerValue(); err == nil && ms >= 0 {
+				consumer.Inactive = time.Duration(ms) * time.Millisecond
+				consumer.ActiveTime = now.Add(-consumer.Inactive)
+			}
+		case "seen-time":
+			if ms, err = value.IntegerValue(); err == nil {
+				consumer.SeenTime = time.UnixMilli(ms)
+			}
+		case "active-time":
+			if ms, err = value.IntegerValue(); err == nil && ms >= 0 {
+				consumer.ActiveTime = time.UnixMilli(ms)
+			}
+		}
+		return err
+	})
+	return consumer, err
+}
+
+// parseStreamPendingEntries reads the PEL of a group, whose entries name
+// their consumer, or of a consumer
+func parseStreamPendingEntries(rp *Reply, withConsumer bool) ([]StreamPendingEntry, error) {
+	size := 3
+	if withConsumer {
+		size = 4
+	}
+	entries := make([]StreamPendingEntry, len(rp.Multi))
+	for i, entryReply := range rp.Multi {
+		if len(entryReply.Multi) != size {
+			return nil, errors.New("invalid reply, not a pending entry")
+		}
+		fields := entryReply.Multi
+		id, err := fields[0].StringValue()
+		if err != nil {
+			return nil, err
+		}
+		entries[i].ID = id
+		if withConsumer {
+			if entries[i].Consumer, err = fields[1].StringValue(); err != nil {
+				return nil, err
+			}
+			fields = fields[1:]
+		}
+		ms, err := fields[1].IntegerValue()
+		if err != nil {
+			return nil, err
+		}
+		entries[i].DeliveryTime = time.UnixMilli(ms)
+		if entries[i].DeliveryCount, err = fields[2].IntegerValue(); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
 }
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -460,14 +461,14 @@ func TestXInfoStream(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	if info == nil {
-		t.Error("Expected stream info")
-	}
 
 	// Should contain basic stream information
-	if length, ok := info["length"]; !ok || length.(int64) != 1 {
+	if info.Length != 1 {
 		t.Error("Stream length not reported correctly")
 	}
+	if info.FirstEntry == nil || info.FirstEntry.Fields["test"] != "value" {
+		t.Error("First entry not reported correctly")
+	}
 }
 
 func TestXInfoGroups(t *testing.T) {
@@ -488,7 +489,7 @@ func TestXInfoGroups(t *testing.T) {
 	}
 
 	// Verify group name
-	if name, ok := groups[0]["name"]; !ok || name.(string) != "testgroup" {
+	if len(groups) > 0 && groups[0].Name != "testgroup" {
 		t.Error("Group name not reported correctly")
 	}
 }
@@ -515,7 +516,7 @@ func TestXInfoConsumers(t *testing.T) {
 	}
 
 	// Verify consumer name
-	if name, ok := consumers[0]["name"]; !ok || name.(string) != "consumer1" {
+	if len(consumers) > 0 && consumers[0].Name != "consumer1" {
 		t.Error("Consumer name not reported correctly")
 	}
 }
@@ -570,3 +571,153 @@ func TestXClaimBasic(t *testing.T) {
 		t.Error("XClaim not working correctly")
 	}
 }
+
+func TestXAutoClaim(t *testing.T) {
+	addr, commands := serveReplies(t,
+		"*3\r\n$3\r\n5-0\r\n*2\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\na\r\n$1\r\n1\r\n$-1\r\n*1\r\n$3\r\n2-0\r\n",
+		// the nil entry is looked up in the PEL and the stream
+		"*2\r\n*4\r\n$3\r\n1-0\r\n$8\r\nconsumer\r\n:0\r\n:2\r\n*4\r\n$3\r\n3-0\r\n$8\r\nconsumer\r\n:10\r\n:1\r\n",
+		"*1\r\n*2\r\n$3\r\n3-0\r\n*2\r\n$1\r\nc\r\n$1\r\n3\r\n",
+		"*1\r\n*4\r\n$3\r\n4-0\r\n$8\r\nconsumer\r\n:0\r\n:2\r\n",
+		"*0\r\n",
+		// RESP3 XREADGROUP replies with a map
+		"%1\r\n$6\r\nstream\r\n*1\r\n*2\r\n$3\r\n6-0\r\n*2\r\n$1\r\nb\r\n$1\r\n2\r\n",
+	)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	res, err := rr.XAutoClaim("stream", "group", "consumer", 60000, "0-0", XAutoClaimOptions{Count: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "XAUTOCLAIM stream group consumer 60000 0-0 COUNT 10" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	for _, expected := range []string{
+		"XPENDING stream group 0-0 (5-0 2 consumer",
+		"XRANGE stream 3-0 3-0",
+		"XPENDING stream group (3-0 (5-0 2 consumer",
+		"XRANGE stream 4-0 4-0",
+	} {
+		if cmd := strings.Join(<-commands, " "); cmd != expected {
+			t.Errorf("expected %q, got %q", expected, cmd)
+		}
+	}
+	if res.Next != "5-0" || len(res.Entries) != 1 || res.Entries[0].Fields["a"] != "1" || strings.Join(res.Deleted, ",") != "2-0,4-0" {
+		t.Errorf("unexpected result %+v", res)
+	}
+	messages, err := rr.XReadGroupWithOptions("group", "consumer", map[string]string{"stream": ">"}, XReadGroupOptions{Count: 1, Block: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 || messages[0].Stream != "stream" || len(messages[0].Entries) != 1 || messages[0].Entries[0].ID != "6-0" {
+		t.Errorf("unexpected messages %+v", messages)
+	}
+}
+
+func TestXInfoParsing(t *testing.T) {
+	entry := "*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\na\r\n$1\r\n1\r\n"
+	stream := map[string]string{
+		// RESP2
+		"list": "*20\r\n$6\r\nlength\r\n:2\r\n$15\r\nradix-tree-keys\r\n:1\r\n$16\r\nradix-tree-nodes\r\n:2\r\n" +
+			"$17\r\nlast-generated-id\r\n$3\r\n2-0\r\n$20\r\nmax-deleted-entry-id\r\n$3\r\n0-0\r\n" +
+			"$13\r\nentries-added\r\n:2\r\n$23\r\nrecorded-first-entry-id\r\n$3\r\n1-0\r\n" +
+			"$6\r\ngroups\r\n:1\r\n$11\r\nfirst-entry\r\n" + entry + "$10\r\nlast-entry\r\n*-1\r\n",
+		// RESP3
+		"map": "%10\r\n+length\r\n:2\r\n+radix-tree-keys\r\n:1\r\n+radix-tree-nodes\r\n:2\r\n" +
+			"+last-generated-id\r\n$3\r\n2-0\r\n+max-deleted-entry-id\r\n$3\r\n0-0\r\n" +
+			"+entries-added\r\n:2\r\n+recorded-first-entry-id\r\n$3\r\n1-0\r\n" +
+			"+groups\r\n:1\r\n+first-entry\r\n" + entry + "+last-entry\r\n_\r\n",
+	}
+	groups := map[string]string{
+		"list": "*1\r\n*12\r\n$4\r\nname\r\n$1\r\ng\r\n$9\r\nconsumers\r\n:2\r\n$7\r\npending\r\n:3\r\n" +
+			"$17\r\nlast-delivered-id\r\n$3\r\n2-0\r\n$12\r\nentries-read\r\n:2\r\n$3\r\nlag\r\n$-1\r\n",
+		"map": "*1\r\n%6\r\n+name\r\n$1\r\ng\r\n+consumers\r\n:2\r\n+pending\r\n:3\r\n" +
+			"+last-delivered-id\r\n$3\r\n2-0\r\n+entries-read\r\n:2\r\n+lag\r\n_\r\n",
+	}
+	consumers := map[string]string{
+		"list": "*1\r\n*8\r\n$4\r\nname\r\n$1\r\nc\r\n$7\r\npending\r\n:1\r\n$4\r\nidle\r\n:1500\r\n$8\r\ninactive\r\n:-1\r\n",
+		"map":  "*1\r\n%4\r\n+name\r\n$1\r\nc\r\n+pending\r\n:1\r\n+idle\r\n:1500\r\n+inactive\r\n:-1\r\n",
+	}
+	for _, form := range []string{"list", "map"} {
+		addr, _ := serveReplies(t, stream[form], groups[form], consumers[form])
+		rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := rr.XInfoStream("s")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Length != 2 || info.LastGeneratedID != "2-0" || info.EntriesAdded != 2 || info.RecordedFirstEntryID != "1-0" ||
+			info.Groups != 1 || info.FirstEntry == nil || info.FirstEntry.Fields["a"] != "1" || info.LastEntry != nil {
+			t.Errorf("%s: unexpected stream info %+v", form, info)
+		}
+		gs, err := rr.XInfoGroups("s")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(gs) != 1 || gs[0].Name != "g" || gs[0].Consumers != 2 || gs[0].Pending != 3 || gs[0].EntriesRead != 2 || gs[0].Lag != -1 {
+			t.Errorf("%s: unexpected groups %+v", form, gs)
+		}
+		cs, err := rr.XInfoConsumers("s", "g")
+		rr.ClosePool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cs) != 1 || cs[0].Name != "c" || cs[0].Pending != 1 || cs[0].Idle != 1500*time.Millisecond ||
+			cs[0].Inactive != -1 || !cs[0].ActiveTime.IsZero() || time.Since(cs[0].SeenTime) < 1500*time.Millisecond {
+			t.Errorf("%s: unexpected consumers %+v", form, cs)
+		}
+	}
+}
+
+func TestXInfoStreamFull(t *testing.T) {
+	reply := "%4\r\n+length\r\n:1\r\n" +
+		"+entries\r\n*1\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\na\r\n$1\r\n1\r\n" +
+		"+last-generated-id\r\n$3\r\n1-0\r\n" +
+		"+groups\r\n*1\r\n%7\r\n+name\r\n$1\r\ng\r\n+last-delivered-id\r\n$3\r\n1-0\r\n" +
+		"+entries-read\r\n:1\r\n+lag\r\n:0\r\n+pel-count\r\n:1\r\n" +
+		"+pending\r\n*1\r\n*4\r\n$3\r\n1-0\r\n$1\r\nc\r\n:1700000000000\r\n:2\r\n" +
+		"+consumers\r\n*1\r\n%5\r\n+name\r\n$1\r\nc\r\n+seen-time\r\n:1700000000000\r\n+active-time\r\n:-1\r\n" +
+		"+pel-count\r\n:1\r\n+pending\r\n*1\r\n*3\r\n$3\r\n1-0\r\n:1700000000000\r\n:2\r\n"
+	capped := "%2\r\n+length\r\n:2\r\n" +
+		"+entries\r\n*1\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\na\r\n$1\r\n1\r\n"
+	addr, commands := serveReplies(t, reply, capped)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	info, err := rr.XInfoStreamFull("s", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd := strings.Join(<-commands, " "); cmd != "XINFO STREAM s FULL COUNT 5" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if info.Length != 1 || len(info.Entries) != 1 || info.FirstEntry.ID != "1-0" || info.LastEntry != &info.Entries[0] ||
+		info.Groups != 1 || len(info.GroupList) != 1 {
+		t.Fatalf("unexpected stream info %+v", info)
+	}
+	delivered := time.UnixMilli(1700000000000)
+	g := info.GroupList[0]
+	if g.Name != "g" || g.Pending != 1 || g.Lag != 0 || g.Consumers != 1 ||
+		len(g.PEL) != 1 || g.PEL[0] != (StreamPendingEntry{ID: "1-0", Consumer: "c", DeliveryTime: delivered, DeliveryCount: 2}) {
+		t.Errorf("unexpected group %+v", g)
+	}
+	c := g.ConsumerList[0]
+	if c.Name != "c" || c.Pending != 1 || !c.SeenTime.Equal(delivered) || c.Idle < time.Since(delivered)-time.Second ||
+		!c.ActiveTime.IsZero() || c.Inactive != -1 || len(c.PEL) != 1 || c.PEL[0].Consumer != "" || c.PEL[0].DeliveryCount != 2 {
+		t.Errorf("unexpected consumer %+v", c)
+	}
+	info, err = rr.XInfoStreamFull("s", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.FirstEntry == nil || info.LastEntry != nil {
+		t.Errorf("expected no last entry when COUNT caps the entries, got %+v", info)
+	}
+}
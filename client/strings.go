@@ -1,7 +1,9 @@
 package client
 
 import (
+	"errors"
 	"strconv"
+	"time"
 )
 
 // Append appends the value at the end of the string which stored at key
@@ -15,6 +17,15 @@ func (r *Redis) Append(key, value string) (int64, error) {
 	return rp.IntegerValue()
 }
 
+// AppendBytes is Append with a binary value.
+func (r *Redis) AppendBytes(key string, value []byte) (int64, error) {
+	rp, err := r.ExecuteCommand("APPEND", key, value)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // BitCount counts the number of set bits (population counting) in a string.
 func (r *Redis) BitCount(key string, start, end int) (int64, error) {
 	rp, err := r.ExecuteCommand("BITCOUNT", key, start, end)
@@ -79,7 +90,7 @@ func (r *Redis) Get(key string) ([]byte, error) {
 }
 
 // GetInt gets the integer value of key.
-// If the key does not exist the special value nil is returned.
+// If the key does not exist ErrNil is returned.
 // An error is returned if the value stored at key is not a string,
 // because GET only handles string values.
 func (r *Redis) GetInt(key string) (int64, error) {
@@ -87,7 +98,17 @@ func (r *Redis) GetInt(key string) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return rp.IntegerValue()
+	if rp.IsNull() {
+		return 0, ErrNil
+	}
+	if rp.Type == IntegerReply {
+		return rp.Integer, nil
+	}
+	value, err := rp.StringValue()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
 }
 
 // GetBit returns the bit value at offset in the string value stored at key.
@@ -126,6 +147,45 @@ func (r *Redis) GetSet(key, value string) ([]byte, error) {
 	return rp.BytesValue()
 }
 
+// GetExOptions represents options for GETEX command.
+// At most one of EX, PX, EXAT, PXAT and Persist may be set, with none the
+// time to live of the key is left unchanged.
+type GetExOptions struct {
+	EX      time.Duration // EX option - expire after, in seconds, sent as PX when not whole seconds
+	PX      time.Duration // PX option - expire after, in milliseconds
+	EXAT    time.Time     // EXAT option - expire at, in unix seconds
+	PXAT    time.Time     // PXAT option - expire at, in unix milliseconds
+	Persist bool          // PERSIST option - remove the time to live
+}
+
+func (opts GetExOptions) args(args []interface{}) ([]interface{}, error) {
+	return expiryArgs(args, opts.EX, opts.PX, opts.EXAT, opts.PXAT, "PERSIST", opts.Persist)
+}
+
+// GetEx gets the value of key and sets or removes its expiration.
+// If the key does not exist nil is returned. Redis 6.2+
+func (r *Redis) GetEx(key string, opts GetExOptions) ([]byte, error) {
+	args, err := opts.args([]interface{}{"GETEX", key})
+	if err != nil {
+		return nil, err
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesValue()
+}
+
+// GetDel gets the value of key and deletes the key.
+// If the key does not exist nil is returned. Redis 6.2+
+func (r *Redis) GetDel(key string) ([]byte, error) {
+	rp, err := r.ExecuteCommand("GETDEL", key)
+	if err != nil {
+		return nil, err
+	}
+	return rp.BytesValue()
+}
+
 // Incr increments the number stored at key by one.
 // If the key does not exist, it is set to 0 before performing the operation.
 // An error is returned if the key contains a value of the wrong type
@@ -165,11 +225,115 @@ func (r *Redis) IncrByFloat(key string, increment float64) (float64, error) {
 	if err != nil {
 		return 0.0, err
 	}
-	s, err := rp.StringValue()
+	return rp.DoubleValue()
+}
+
+// LCS returns the longest common subsequence of the strings stored at
+// key1 and key2. Redis 7.0+
+func (r *Redis) LCS(key1, key2 string) (string, error) {
+	rp, err := r.ExecuteCommand("LCS", key1, key2)
 	if err != nil {
-		return 0.0, err
+		return "", err
+	}
+	return rp.StringValue()
+}
+
+// LCSLen returns the length of the longest common subsequence of the strings
+// stored at key1 and key2. Redis 7.0+
+func (r *Redis) LCSLen(key1, key2 string) (int64, error) {
+	rp, err := r.ExecuteCommand("LCS", key1, key2, "LEN")
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// LCSOptions represents options for LCS IDX
+type LCSOptions struct {
+	MinMatchLen  int64 // MINMATCHLEN option - skip shorter matches
+	WithMatchLen bool  // WITHMATCHLEN option - return the length of each match
+}
+
+// LCSRange is a range of a string, both ends inclusive
+type LCSRange struct {
+	Start int64
+	End   int64
+}
+
+// LCSMatch is a common part of the two strings compared by LCS
+type LCSMatch struct {
+	Key1 LCSRange // position in the string at key1
+	Key2 LCSRange // position in the string at key2
+	Len  int64    // only set with WithMatchLen
+}
+
+// LCSResult is the result of LCS IDX
+type LCSResult struct {
+	Matches []LCSMatch // from the end of the strings to their start
+	Len     int64      // length of the longest common subsequence
+}
+
+// LCSIdx returns the positions of the matches making the longest common
+// subsequence of the strings stored at key1 and key2. Redis 7.0+
+func (r *Redis) LCSIdx(key1, key2 string, opts LCSOptions) (LCSResult, error) {
+	args := []interface{}{"LCS", key1, key2, "IDX"}
+	if opts.MinMatchLen > 0 {
+		args = append(args, "MINMATCHLEN", opts.MinMatchLen)
+	}
+	if opts.WithMatchLen {
+		args = append(args, "WITHMATCHLEN")
+	}
+	var res LCSResult
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return res, err
+	}
+	err = eachField(rp, func(field string, value *Reply) (err error) {
+		switch field {
+		case "len":
+			res.Len, err = value.IntegerValue()
+		case "matches":
+			res.Matches = make([]LCSMatch, 0, len(value.Multi))
+			for _, m := range value.Multi {
+				var match LCSMatch
+				if match, err = parseLCSMatch(m); err != nil {
+					return err
+				}
+				res.Matches = append(res.Matches, match)
+			}
+		}
+		return err
+	})
+	return res, err
+}
+
+// parseLCSMatch reads a match of LCS IDX, the ranges in both strings
+// followed by the match length with WITHMATCHLEN
+func parseLCSMatch(rp *Reply) (LCSMatch, error) {
+	var match LCSMatch
+	if len(rp.Multi) < 2 {
+		return match, errors.New("invalid LCS match reply")
+	}
+	for i, rng := range []*LCSRange{&match.Key1, &match.Key2} {
+		ends := rp.Multi[i].Multi
+		if len(ends) != 2 {
+			return match, errors.New("invalid LCS match range reply")
+		}
+		var err error
+		if rng.Start, err = ends[0].IntegerValue(); err != nil {
+			return match, err
+		}
+		if rng.End, err = ends[1].IntegerValue(); err != nil {
+			return match, err
+		}
 	}
-	return strconv.ParseFloat(s, 64)
+	if len(rp.Multi) > 2 {
+		var err error
+		if match.Len, err = rp.Multi[2].IntegerValue(); err != nil {
+			return match, err
+		}
+	}
+	return match, nil
 }
 
 // MGet returns the values of all specified keys.
@@ -194,6 +358,20 @@ func (r *Redis) MSet(pairs map[string]string) error {
 	return err
 }
 
+// MSetBytes is MSet with binary values.
+func (r *Redis) MSetBytes(pairs map[string][]byte) error {
+	args := make([]interface{}, 0, 1+2*len(pairs))
+	args = append(args, "MSET")
+	for key, value := range pairs {
+		args = append(args, key, value)
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
 // MSetnx sets the given keys to their respective values.
 // MSETNX will not perform any operation at all even if just a single key already exists.
 // True if the all the keys were set.
@@ -218,6 +396,15 @@ func (r *Redis) Set(key, value string) error {
 	return rp.OKValue()
 }
 
+// SetBytes is Set with a binary value.
+func (r *Redis) SetBytes(key string, value []byte) error {
+	rp, err := r.ExecuteCommand("SET", key, value)
+	if err != nil {
+		return err
+	}
+	return rp.OKValue()
+}
+
 // PSetex works exactly like SETEX with the sole difference that
 // the expire time is specified in milliseconds instead of seconds.
 func (r *Redis) PSetex(key string, milliseconds int, value string) error {
@@ -235,6 +422,116 @@ func (r *Redis) SetMX(key, value string) error {
 	return rp.OKValue()
 }
 
+// SetOptions represents options for SET command.
+// At most one of EX, PX, EXAT, PXAT and KeepTTL may be set, and only one of
+// NX and XX.
+type SetOptions struct {
+	EX      time.Duration // EX option - expire after, in seconds, sent as PX when not whole seconds
+	PX      time.Duration // PX option - expire after, in milliseconds
+	EXAT    time.Time     // EXAT option - expire at, in unix seconds (Redis 6.2+)
+	PXAT    time.Time     // PXAT option - expire at, in unix milliseconds (Redis 6.2+)
+	KeepTTL bool          // KEEPTTL option - retain the time to live of the key (Redis 6.0+)
+	NX      bool          // NX option - only set if the key does not exist
+	XX      bool          // XX option - only set if the key exists
+	Get     bool          // GET option - return the previous value (Redis 6.2+, with NX 7.0+)
+}
+
+// expiryArgs appends to args the EX, PX, EXAT, PXAT or last (KEEPTTL or
+// PERSIST) option shared by SET, GETEX and HSETEX, sending an EX which is not
+// whole seconds as PX.
+func expiryArgs(args []interface{}, ex, px time.Duration, exat, pxat time.Time, last string, keep bool) ([]interface{}, error) {
+	expiries := 0
+	if ex > 0 {
+		if ex%time.Second == 0 {
+			args = append(args, "EX", int64(ex/time.Second))
+		} else {
+			args = append(args, "PX", milliseconds(ex))
+		}
+		expiries++
+	}
+	if px > 0 {
+		args = append(args, "PX", milliseconds(px))
+		expiries++
+	}
+	if !exat.IsZero() {
+		args = append(args, "EXAT", exat.Unix())
+		expiries++
+	}
+	if !pxat.IsZero() {
+		args = append(args, "PXAT", pxat.UnixMilli())
+		expiries++
+	}
+	if keep {
+		args = append(args, last)
+		expiries++
+	}
+	if expiries > 1 {
+		return nil, errors.New("only one of EX, PX, EXAT, PXAT and " + last + " may be set")
+	}
+	return args, nil
+}
+
+// milliseconds converts a positive duration to milliseconds, rounding one
+// under a millisecond up as Redis rejects an expiry of 0
+func milliseconds(d time.Duration) int64 {
+	if d > 0 && d < time.Millisecond {
+		return 1
+	}
+	return int64(d / time.Millisecond)
+}
+
+// SetResult is the result of SetWithOptions
+type SetResult struct {
+	// Set is false when NX or XX prevented the SET
+	Set bool
+	// Previous is the value stored at key before the SET when Get is set,
+	// nil if the key did not exist
+	Previous []byte
+}
+
+// SetWithOptions sets key to hold value, a string, []byte or number,
+// with the options of SET.
+func (r *Redis) SetWithOptions(key string, value interface{}, opts SetOptions) (SetResult, error) {
+	args, err := expiryArgs([]interface{}{"SET", key, value}, opts.EX, opts.PX, opts.EXAT, opts.PXAT, "KEEPTTL", opts.KeepTTL)
+	if err != nil {
+		return SetResult{}, err
+	}
+	if opts.NX && opts.XX {
+		return SetResult{}, errors.New("NX and XX are mutually exclusive")
+	}
+	if opts.NX {
+		args = append(args, "NX")
+	}
+	if opts.XX {
+		args = append(args, "XX")
+	}
+	if opts.Get {
+		args = append(args, "GET")
+	}
+	rp, err := r.ExecuteCommand(args...)
+	if err != nil {
+		return SetResult{}, err
+	}
+	if !opts.Get {
+		if rp.IsNull() {
+			return SetResult{}, nil
+		}
+		return SetResult{Set: true}, rp.OKValue()
+	}
+	previous, err := rp.BytesValue()
+	if err != nil {
+		return SetResult{}, err
+	}
+	// with GET the reply is the previous value whether the SET happened or not
+	res := SetResult{Set: true, Previous: previous}
+	if opts.NX {
+		res.Set = previous == nil
+	} else if opts.XX {
+		res.Set = previous != nil
+	}
+	return res, nil
+}
+
 // SetBit sets or clears the bit at offset in the string value stored at key.
 // Integer reply: the original bit value stored at offset.
 func (r *Redis) SetBit(key string, offset, value int) (int64, error) {
@@ -274,6 +571,15 @@ func (r *Redis) SetRange(key string, offset int, value string) (int64, error) {
 	return rp.IntegerValue()
 }
 
+// SetRangeBytes is SetRange with a binary value.
+func (r *Redis) SetRangeBytes(key string, offset int, value []byte) (int64, error) {
+	rp, err := r.ExecuteCommand("SETRANGE", key, offset, value)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
 // StrLen returns the length of the string value stored at key.
 // An error is returned when key holds a non-string value.
 // Integer reply: the length of the string at key, or 0 when key does not exist.
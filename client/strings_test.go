@@ -1,7 +1,9 @@
 package client
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAppend(t *testing.T) {
@@ -269,6 +271,54 @@ func BenchmarkSet(b *testing.B) {
 	}
 }
 
+func TestSetWithOptions(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	cases := []struct {
+		opts  SetOptions
+		reply string
+		cmd   string
+		res   SetResult
+	}{
+		{SetOptions{}, "+OK\r\n", "SET key value", SetResult{Set: true}},
+		{SetOptions{EX: 10 * time.Second, NX: true}, "$-1\r\n", "SET key value EX 10 NX", SetResult{}},
+		{SetOptions{PX: 1500 * time.Millisecond, XX: true}, "+OK\r\n", "SET key value PX 1500 XX", SetResult{Set: true}},
+		{SetOptions{EX: 500 * time.Millisecond}, "+OK\r\n", "SET key value PX 500", SetResult{Set: true}},
+		{SetOptions{EX: 1500 * time.Millisecond}, "+OK\r\n", "SET key value PX 1500", SetResult{Set: true}},
+		{SetOptions{EX: 500 * time.Microsecond}, "+OK\r\n", "SET key value PX 1", SetResult{Set: true}},
+		{SetOptions{PX: time.Microsecond}, "+OK\r\n", "SET key value PX 1", SetResult{Set: true}},
+		{SetOptions{EXAT: at}, "+OK\r\n", "SET key value EXAT 1700000000", SetResult{Set: true}},
+		{SetOptions{PXAT: at, Get: true}, "$-1\r\n", "SET key value PXAT 1700000000000 GET", SetResult{Set: true}},
+		{SetOptions{KeepTTL: true, Get: true}, "$3\r\nold\r\n", "SET key value KEEPTTL GET", SetResult{Set: true, Previous: []byte("old")}},
+		{SetOptions{NX: true, Get: true}, "$3\r\nold\r\n", "SET key value NX GET", SetResult{Previous: []byte("old")}},
+		{SetOptions{XX: true, Get: true}, "$-1\r\n", "SET key value XX GET", SetResult{}},
+	}
+	for _, c := range cases {
+		addr, commands := serveReplies(t, c.reply)
+		rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := rr.SetWithOptions("key", []byte("value"), c.opts)
+		rr.ClosePool()
+		if err != nil {
+			t.Errorf("%s: %v", c.cmd, err)
+			continue
+		}
+		if cmd := strings.Join(<-commands, " "); cmd != c.cmd {
+			t.Errorf("expected %q, got %q", c.cmd, cmd)
+		}
+		if res.Set != c.res.Set || string(res.Previous) != string(c.res.Previous) || (res.Previous == nil) != (c.res.Previous == nil) {
+			t.Errorf("%s: expected %+v, got %+v", c.cmd, c.res, res)
+		}
+	}
+	if _, err := r.SetWithOptions("key", "value", SetOptions{EX: time.Second, KeepTTL: true}); err == nil {
+		t.Error("expected an error for EX with KEEPTTL")
+	}
+	if _, err := r.SetWithOptions("key", "value", SetOptions{NX: true, XX: true}); err == nil {
+		t.Error("expected an error for NX with XX")
+	}
+}
+
 func TestSetBit(t *testing.T) {
 	if _, err := r.SetBit("key", 7, 1); err != nil {
 		t.Error(err)
@@ -320,3 +370,125 @@ func TestStrlen(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestGetEx(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	cases := []struct {
+		opts GetExOptions
+		cmd  string
+	}{
+		{GetExOptions{}, "GETEX key"},
+		{GetExOptions{EX: time.Minute}, "GETEX key EX 60"},
+		{GetExOptions{PX: 250 * time.Millisecond}, "GETEX key PX 250"},
+		{GetExOptions{EX: 1500 * time.Millisecond}, "GETEX key PX 1500"},
+		{GetExOptions{EXAT: at}, "GETEX key EXAT 1700000000"},
+		{GetExOptions{PXAT: at}, "GETEX key PXAT 1700000000000"},
+		{GetExOptions{Persist: true}, "GETEX key PERSIST"},
+	}
+	for _, c := range cases {
+		addr, commands := serveReplies(t, "$5\r\nvalue\r\n")
+		rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := rr.GetEx("key", c.opts)
+		rr.ClosePool()
+		if err != nil {
+			t.Errorf("%s: %v", c.cmd, err)
+			continue
+		}
+		if cmd := strings.Join(<-commands, " "); cmd != c.cmd {
+			t.Errorf("expected %q, got %q", c.cmd, cmd)
+		}
+		if string(value) != "value" {
+			t.Errorf("%s: unexpected value %q", c.cmd, value)
+		}
+	}
+	if _, err := r.GetEx("key", GetExOptions{EX: time.Second, Persist: true}); err == nil {
+		t.Error("expected an error for EX with PERSIST")
+	}
+}
+
+func TestLCSIdx(t *testing.T) {
+	replies := []string{
+		// RESP2
+		"*4\r\n$7\r\nmatches\r\n*2\r\n" +
+			"*3\r\n*2\r\n:4\r\n:7\r\n*2\r\n:5\r\n:8\r\n:4\r\n" +
+			"*3\r\n*2\r\n:2\r\n:3\r\n*2\r\n:0\r\n:1\r\n:2\r\n" +
+			"$3\r\nlen\r\n:6\r\n",
+		// RESP3
+		"%2\r\n+matches\r\n*2\r\n" +
+			"*3\r\n*2\r\n:4\r\n:7\r\n*2\r\n:5\r\n:8\r\n:4\r\n" +
+			"*3\r\n*2\r\n:2\r\n:3\r\n*2\r\n:0\r\n:1\r\n:2\r\n" +
+			"+len\r\n:6\r\n",
+	}
+	for _, reply := range replies {
+		addr, commands := serveReplies(t, reply)
+		rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := rr.LCSIdx("key1", "key2", LCSOptions{MinMatchLen: 2, WithMatchLen: true})
+		rr.ClosePool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd := strings.Join(<-commands, " "); cmd != "LCS key1 key2 IDX MINMATCHLEN 2 WITHMATCHLEN" {
+			t.Errorf("unexpected command %q", cmd)
+		}
+		expected := []LCSMatch{
+			{Key1: LCSRange{4, 7}, Key2: LCSRange{5, 8}, Len: 4},
+			{Key1: LCSRange{2, 3}, Key2: LCSRange{0, 1}, Len: 2},
+		}
+		if res.Len != 6 || len(res.Matches) != 2 || res.Matches[0] != expected[0] || res.Matches[1] != expected[1] {
+			t.Errorf("unexpected result %+v", res)
+		}
+	}
+}
+
+func TestBytesVariants(t *testing.T) {
+	value := []byte{0, 1, 2, 0xff}
+	addr, commands := serveReplies(t, "+OK\r\n", ":4\r\n", ":6\r\n", "+OK\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	if err := rr.SetBytes("key", value); err != nil {
+		t.Error(err)
+	}
+	if n, err := rr.AppendBytes("key", value); err != nil || n != 4 {
+		t.Errorf("AppendBytes: %d, %v", n, err)
+	}
+	if n, err := rr.SetRangeBytes("key", 2, value); err != nil || n != 6 {
+		t.Errorf("SetRangeBytes: %d, %v", n, err)
+	}
+	if err := rr.MSetBytes(map[string][]byte{"key": value}); err != nil {
+		t.Error(err)
+	}
+	for _, expected := range [][]string{
+		{"SET", "key", string(value)},
+		{"APPEND", "key", string(value)},
+		{"SETRANGE", "key", "2", string(value)},
+		{"MSET", "key", string(value)},
+	} {
+		if cmd := <-commands; strings.Join(cmd, " ") != strings.Join(expected, " ") {
+			t.Errorf("expected %q, got %q", expected, cmd)
+		}
+	}
+}
+
+func TestIncrByFloatRESP3(t *testing.T) {
+	addr, _ := serveReplies(t, ",3.5\r\n", "$3\r\n4.5\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	// RESP3 double, then RESP2 bulk string
+	for _, expected := range []float64{3.5, 4.5} {
+		if f, err := rr.IncrByFloat("key", 1); err != nil || f != expected {
+			t.Errorf("expected %v, got %v %v", expected, f, err)
+		}
+	}
+}
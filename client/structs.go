@@ -0,0 +1,355 @@
+package client
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Struct fields are mapped to hash fields with the redis tag, the same tag
+// used by the structures package:
+//  type User struct {
+//  	Name    string    `redis:"name"`
+//  	Age     int       `redis:"age,omitempty"`
+//  	Created time.Time `redis:"created"`
+//  	Secret  string    `redis:"-"`
+//  }
+// Exported fields without a tag use the field name. Fields tagged "-" are
+// skipped, and omitempty leaves out zero values when writing. Anonymous
+// struct fields without a tag are flattened.
+//
+// Supported field types are strings, []byte, integers, floats, bools,
+// types implementing encoding.TextMarshaler and TextUnmarshaler such as
+// time.Time, and pointers to any of these. Bools are written as 1 and 0,
+// nil pointers are left out.
+
+// structField is a struct field mapped to a hash field
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+var structFieldCache sync.Map // reflect.Type -> []structField
+
+// structFields returns the hash fields of a struct type
+func structFields(t reflect.Type) []structField {
+	if fields, ok := structFieldCache.Load(t); ok {
+		return fields.([]structField)
+	}
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("redis")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for _, sub := range structFields(f.Type) {
+				sub.index = append([]int{i}, sub.index...)
+				fields = append(fields, sub)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, structField{
+			name:      name,
+			index:     f.Index,
+			omitEmpty: opts == "omitempty",
+		})
+	}
+	structFieldCache.Store(t, fields)
+	return fields
+}
+
+// structArgs returns the field value pairs of a struct, or pointer to one,
+// as HSET arguments
+func structArgs(v interface{}) ([]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.New("cannot map nil pointer to hash")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot map %s to hash, not a struct", rv.Type())
+	}
+	var args []interface{}
+	for _, field := range structFields(rv.Type()) {
+		fv := rv.FieldByIndex(field.index)
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+		value, ok, err := formatValue(fv)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", field.name, err)
+		}
+		if ok {
+			args = append(args, field.name, value)
+		}
+	}
+	return args, nil
+}
+
+// formatValue returns the string or []byte written for a field value,
+// ok is false for nil pointers
+func formatValue(v reflect.Value) (value interface{}, ok bool, err error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		v = v.Elem()
+	}
+	if m, isMarshaler := v.Interface().(encoding.TextMarshaler); isMarshaler {
+		text, err := m.MarshalText()
+		if err != nil {
+			return nil, false, err
+		}
+		return text, true, nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true, nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "1", true, nil
+		}
+		return "0", true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), true, nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), true, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), true, nil
+		}
+	}
+	return nil, false, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// setValue parses s into v, allocating nil pointers on the way
+func setValue(v reflect.Value, s []byte) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText(s)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(string(s))
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(string(s))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(string(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(string(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := parseDouble(string(s))
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes(append([]byte(nil), s...))
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// scanStruct sets the fields of the struct v from hash field value pairs
+func scanStruct(v reflect.Value, pairs []*Reply) error {
+	values := make(map[string]*Reply, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		key, err := pairs[i].keyString()
+		if err != nil {
+			return err
+		}
+		values[key] = pairs[i+1]
+	}
+	for _, field := range structFields(v.Type()) {
+		rp, ok := values[field.name]
+		if !ok || rp.IsNull() {
+			continue
+		}
+		fv := v.FieldByIndex(field.index)
+		if err := rp.scan(fv); err != nil {
+			return fmt.Errorf("field %s: %v", field.name, err)
+		}
+	}
+	return nil
+}
+
+// HSetStruct sets the hash stored at key from the fields of v, a struct or a
+// pointer to one, mapped with the redis tag.
+// Integer reply: the number of fields that were added.
+func (r *Redis) HSetStruct(key string, v interface{}) (int64, error) {
+	fields, err := structArgs(v)
+	if err != nil {
+		return 0, err
+	}
+	if len(fields) == 0 {
+		return 0, errors.New("no fields to set")
+	}
+	rp, err := r.ExecuteCommand(append([]interface{}{"HSET", key}, fields...)...)
+	if err != nil {
+		return 0, err
+	}
+	return rp.IntegerValue()
+}
+
+// HGetAllInto reads the hash stored at key into dest, a pointer to a struct
+// mapped with the redis tag. Fields missing from the hash are left
+// unchanged. ErrNil is returned if key does not exist.
+func (r *Redis) HGetAllInto(key string, dest interface{}) error {
+	rp, err := r.ExecuteCommand("HGETALL", key)
+	if err != nil {
+		return err
+	}
+	if len(rp.Multi) == 0 {
+		return ErrNil
+	}
+	return rp.Scan(dest)
+}
+
+// Scan stores the reply in dest, which must be a pointer.
+// Scalar replies are converted to the type dest points to, which may be
+// a string, []byte, integer, float, bool or encoding.TextUnmarshaler.
+// Multi bulk and map replies are stored in a pointer to a slice, a map with
+// string keys, or a struct mapped with the redis tag, whose fields are read
+// from alternating field names and values as HGETALL returns them.
+// ErrNil is returned for a null reply.
+func (rp *Reply) Scan(dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.New("scan destination must be a non-nil pointer")
+	}
+	return rp.scan(v.Elem())
+}
+
+// scan stores the reply in v
+func (rp *Reply) scan(v reflect.Value) error {
+	if rp.Type == ErrorReply {
+		return newRedisError(rp.Error)
+	}
+	if rp.IsNull() {
+		return ErrNil
+	}
+	if rp.isAggregate() {
+		return rp.scanAggregate(v)
+	}
+	var s []byte
+	switch rp.Type {
+	case StatusReply:
+		s = []byte(rp.Status)
+	case IntegerReply:
+		s = strconv.AppendInt(nil, rp.Integer, 10)
+	case BulkReply, VerbatimReply:
+		s = rp.Bulk
+	case DoubleReply:
+		s = strconv.AppendFloat(nil, rp.Double, 'g', -1, 64)
+	case BooleanReply:
+		s = strconv.AppendBool(nil, rp.Boolean)
+	case BigNumberReply:
+		s = []byte(rp.BigNumber.String())
+	default:
+		return errors.New("invalid reply type, not scannable")
+	}
+	if err := setValue(v, s); err != nil {
+		return fmt.Errorf("cannot convert %q to %s: %v", s, v.Type(), err)
+	}
+	return nil
+}
+
+// scanAggregate stores a multi bulk, map or set reply in v
+func (rp *Reply) scanAggregate(v reflect.Value) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if len(rp.Multi)%2 != 0 {
+			return errors.New("invalid reply, odd number of hash elements")
+		}
+		return scanStruct(v, rp.Multi)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		slice := reflect.MakeSlice(v.Type(), len(rp.Multi), len(rp.Multi))
+		for i, subrp := range rp.Multi {
+			if subrp.IsNull() {
+				continue
+			}
+			if err := subrp.scan(slice.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %v", i, err)
+			}
+		}
+		v.Set(slice)
+		return nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if len(rp.Multi)%2 != 0 {
+			return errors.New("invalid reply, odd number of map elements")
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(rp.Multi)/2)
+		for i := 0; i+1 < len(rp.Multi); i += 2 {
+			key, err := rp.Multi[i].keyString()
+			if err != nil {
+				return err
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if !rp.Multi[i+1].IsNull() {
+				if err := rp.Multi[i+1].scan(elem); err != nil {
+					return fmt.Errorf("key %s: %v", key, err)
+				}
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+		return nil
+	}
+	return fmt.Errorf("cannot scan multi bulk reply into %s", v.Type())
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testProfile struct {
+	Bio string `redis:"bio"`
+}
+
+type testUser struct {
+	testProfile
+	Name     string    `redis:"name"`
+	Age      int       `redis:"age,omitempty"`
+	Score    float64   `redis:"score"`
+	Active   bool      `redis:"active"`
+	Created  time.Time `redis:"created"`
+	Avatar   []byte    `redis:"avatar"`
+	Nickname *string   `redis:"nickname"`
+	Secret   string    `redis:"-"`
+	Untagged uint16
+	internal string
+}
+
+func TestStructArgs(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	u := testUser{
+		testProfile: testProfile{Bio: "hi"},
+		Name:        "bob",
+		Score:       1.5,
+		Active:      true,
+		Created:     created,
+		Secret:      "hidden",
+		Untagged:    7,
+	}
+	args, err := structArgs(&u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := make(map[string]string)
+	for i := 0; i < len(args); i += 2 {
+		switch v := args[i+1].(type) {
+		case string:
+			fields[args[i].(string)] = v
+		case []byte:
+			fields[args[i].(string)] = string(v)
+		}
+	}
+	expected := map[string]string{
+		"bio":      "hi",
+		"name":     "bob",
+		"score":    "1.5",
+		"active":   "1",
+		"created":  "2024-05-01T12:00:00Z",
+		"avatar":   "",
+		"Untagged": "7",
+	}
+	if len(fields) != len(expected) {
+		t.Errorf("expected %v, got %v", expected, fields)
+	}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("field %s = %q, should be %q", k, fields[k], v)
+		}
+	}
+	if _, err := structArgs("not a struct"); err == nil {
+		t.Error("expected error for non struct")
+	}
+}
+
+func TestReplyScanStruct(t *testing.T) {
+	rp, err := recvFrom("*12\r\n$4\r\nname\r\n$3\r\nbob\r\n$3\r\nage\r\n$2\r\n42\r\n$6\r\nactive\r\n$1\r\n1\r\n$7\r\ncreated\r\n$20\r\n2024-05-01T12:00:00Z\r\n$8\r\nnickname\r\n$2\r\nbb\r\n$3\r\nbio\r\n$2\r\nhi\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var u testUser
+	if err := rp.Scan(&u); err != nil {
+		t.Fatal(err)
+	}
+	if u.Name != "bob" || u.Age != 42 || !u.Active || u.Bio != "hi" {
+		t.Errorf("unexpected user %+v", u)
+	}
+	if !u.Created.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected created %v", u.Created)
+	}
+	if u.Nickname == nil || *u.Nickname != "bb" {
+		t.Errorf("unexpected nickname %v", u.Nickname)
+	}
+
+	rp, _ = recvFrom("%1\r\n+age\r\n$3\r\nold\r\n")
+	err = rp.Scan(&u)
+	if err == nil || !strings.Contains(err.Error(), "age") {
+		t.Errorf("expected conversion error naming the field, got %v", err)
+	}
+}
+
+func TestReplyScanValues(t *testing.T) {
+	rp, _ := recvFrom(":12\r\n")
+	var n int
+	if err := rp.Scan(&n); err != nil || n != 12 {
+		t.Errorf("unexpected %d %v", n, err)
+	}
+	var f float64
+	rp, _ = recvFrom("$4\r\n2.25\r\n")
+	if err := rp.Scan(&f); err != nil || f != 2.25 {
+		t.Errorf("unexpected %v %v", f, err)
+	}
+	var list []int
+	rp, _ = recvFrom("*2\r\n:1\r\n$1\r\n2\r\n")
+	if err := rp.Scan(&list); err != nil || len(list) != 2 || list[1] != 2 {
+		t.Errorf("unexpected %v %v", list, err)
+	}
+	var m map[string]int
+	rp, _ = recvFrom("%1\r\n$1\r\na\r\n:3\r\n")
+	if err := rp.Scan(&m); err != nil || m["a"] != 3 {
+		t.Errorf("unexpected %v %v", m, err)
+	}
+	rp, _ = recvFrom("$-1\r\n")
+	if err := rp.Scan(&n); !errors.Is(err, ErrNil) {
+		t.Errorf("expected ErrNil, got %v", err)
+	}
+	if err := rp.Scan(n); err == nil {
+		t.Error("expected error for non pointer destination")
+	}
+}
+
+func TestHSetStruct(t *testing.T) {
+	r.Del("key")
+	defer r.Del("key")
+	nick := "bb"
+	in := testUser{Name: "bob", Age: 42, Score: 0.5, Active: true, Created: time.Now().UTC().Truncate(time.Second), Nickname: &nick}
+	n, err := r.HSetStruct("key", in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 {
+		t.Error("expected fields to be added")
+	}
+	var out testUser
+	if err := r.HGetAllInto("key", &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != in.Name || out.Age != in.Age || out.Score != in.Score || !out.Active || !out.Created.Equal(in.Created) || *out.Nickname != nick {
+		t.Errorf("expected %+v, got %+v", in, out)
+	}
+	r.Del("key")
+	if err := r.HGetAllInto("key", &out); err != ErrNil {
+		t.Errorf("expected ErrNil, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 )
 
@@ -100,3 +101,197 @@ func (t *Transaction) Command(args ...interface{}) error {
 	}
 	return nil
 }
+
+// DefaultWatchAttempts is the number of times Watch runs a transaction whose
+// watched keys keep changing
+const DefaultWatchAttempts = 10
+
+// ErrTxFailed is returned when EXEC was aborted as a watched key changed,
+// on every attempt of Watch
+var ErrTxFailed = errors.New("redis: transaction failed, watched keys changed")
+
+var errPinnedBroken = errors.New("transaction connection failed")
+
+// pinnedConn is a connection taken out of the pool for the commands of a
+// transaction
+type pinnedConn struct {
+	conn   *connection
+	broken bool
+}
+
+func (pc *pinnedConn) exchange(ctx context.Context, r *Redis, request []byte, n int) ([]*Reply, error) {
+	if pc.broken {
+		return nil, errPinnedBroken
+	}
+	rps, err := r.transfer(ctx, pc.conn, request, n)
+	if len(rps) < n {
+		pc.broken = true
+	}
+	return rps, err
+}
+
+// Tx is an optimistic transaction started by Redis.Watch.
+// The command methods of Tx run on the connection holding the WATCH, use them
+// to read the watched keys, then queue the writes with Exec.
+type Tx struct {
+	*Redis
+	execed bool
+}
+
+// Watch runs fn in an optimistic transaction, see WatchWithAttempts.
+// It makes up to DefaultWatchAttempts attempts.
+func (r *Redis) Watch(ctx context.Context, fn func(tx *Tx) error, keys ...string) error {
+	return r.WatchWithAttempts(ctx, DefaultWatchAttempts, fn, keys...)
+}
+
+// WatchWithAttempts watches keys, then calls fn, which reads them through tx
+// and queues its writes with tx.Exec:
+//
+//	err := client.Watch(ctx, func(tx *Tx) error {
+//		n, err := tx.GetInt("counter")
+//		if err != nil && err != ErrNil {
+//			return err
+//		}
+//		return tx.Exec(func(pipe *Pipeline) error {
+//			pipe.Set("counter", strconv.FormatInt(n*2, 10))
+//			return nil
+//		})
+//	}, "counter")
+//
+// If a watched key is modified before EXEC, the transaction is aborted and
+// fn is called again, up to attempts times in total, after which ErrTxFailed
+// is returned. Any other error returned by fn is returned as is.
+//
+// On a ClusterClient all keys must be in the same hash slot.
+func (r *Redis) WatchWithAttempts(ctx context.Context, attempts int, fn func(tx *Tx) error, keys ...string) error {
+	node := r
+	if r.cluster != nil {
+		slot := -1
+		if len(keys) > 0 {
+			slot = HashSlot(keys[0])
+		}
+		var err error
+		if node, err = r.cluster.nodeForSlot(slot); err != nil {
+			return err
+		}
+	}
+	for attempt := 1; ; attempt++ {
+		err := node.watch(ctx, fn, keys)
+		if err != ErrTxFailed || attempt >= attempts {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// watch makes one attempt of a transaction on a connection taken from the
+// pool
+func (r *Redis) watch(ctx context.Context, fn func(tx *Tx) error, keys []string) error {
+	c, err := r.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	pinned := &pinnedConn{conn: c}
+	defer func() {
+		if pinned.broken {
+			r.pool.Discard(c)
+		} else {
+			r.pool.Put(c)
+		}
+	}()
+	rr := *r
+	rr.ctx = ctx
+	rr.pinned = pinned
+	tx := &Tx{Redis: &rr}
+	if len(keys) > 0 {
+		if err := tx.Watch(keys...); err != nil {
+			return err
+		}
+	}
+	err = fn(tx)
+	if !tx.execed && !pinned.broken {
+		if _, err := tx.ExecuteCommand("UNWATCH"); err != nil {
+			pinned.broken = true
+		}
+	}
+	return err
+}
+
+// Watch adds keys to the keys watched by the transaction
+func (tx *Tx) Watch(keys ...string) error {
+	_, err := tx.ExecuteCommand(packArgs("WATCH", keys)...)
+	return err
+}
+
+// Exec runs the commands fn queues on pipe in MULTI/EXEC. Their results are
+// set on the Cmd handles returned by pipe as with Pipeline.Exec, commands
+// failing inside EXEC report their error only through their Cmd.
+// ErrTxFailed is returned if a watched key was modified, and the
+// EXECABORT error if a command was rejected when queued.
+func (tx *Tx) Exec(fn func(pipe *Pipeline) error) error {
+	pipe := tx.Pipeline()
+	if err := fn(pipe); err != nil {
+		return err
+	}
+	cmds := pipe.cmds
+	pipe.cmds = nil
+	tx.execed = true
+	request, err := packCommand("MULTI")
+	if err != nil {
+		return err
+	}
+	for _, cmd := range cmds {
+		b, err := packCommand(cmd.Args()...)
+		if err != nil {
+			return err
+		}
+		request = append(request, b...)
+	}
+	b, _ := packCommand("EXEC")
+	request = append(request, b...)
+
+	rps, err := tx.exchange(tx.Context(), request, len(cmds)+2)
+	if len(rps) < len(cmds)+2 {
+		for _, cmd := range cmds {
+			cmd.settle(tx.Redis, nil, err)
+		}
+		return err
+	}
+	exec := rps[len(rps)-1]
+	switch {
+	case rps[0].Type == ErrorReply:
+		err := newRedisError(rps[0].Error)
+		for _, cmd := range cmds {
+			cmd.settle(tx.Redis, nil, err)
+		}
+		return err
+	case exec.Type == ErrorReply:
+		// commands rejected when queued have their own error reply
+		err := newRedisError(exec.Error)
+		for i, cmd := range cmds {
+			if queued := rps[i+1]; queued.Type == ErrorReply {
+				cmd.settle(tx.Redis, queued, nil)
+			} else {
+				cmd.settle(tx.Redis, nil, err)
+			}
+		}
+		return err
+	case exec.IsNull():
+		for _, cmd := range cmds {
+			cmd.settle(tx.Redis, nil, ErrTxFailed)
+		}
+		return ErrTxFailed
+	case len(exec.Multi) != len(cmds):
+		err := errors.New("invalid EXEC reply, reply count mismatch")
+		for _, cmd := range cmds {
+			cmd.settle(tx.Redis, nil, err)
+		}
+		return err
+	}
+	for i, cmd := range cmds {
+		cmd.settle(tx.Redis, exec.Multi[i], nil)
+	}
+	return nil
+}
@@ -1,7 +1,12 @@
 package client
 
 import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestTransaction(t *testing.T) {
@@ -68,3 +73,104 @@ func TestDiscard(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestWatchTx(t *testing.T) {
+	addr, commands := serveReplies(t,
+		"+OK\r\n", "$1\r\n5\r\n",
+		"+OK\r\n", "+QUEUED\r\n", "+QUEUED\r\n", "*2\r\n+OK\r\n:6\r\n",
+	)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	var incr *IntCmd
+	err = rr.Watch(context.Background(), func(tx *Tx) error {
+		n, err := tx.GetInt("counter")
+		if err != nil {
+			return err
+		}
+		return tx.Exec(func(pipe *Pipeline) error {
+			pipe.Set("seen", strconv.FormatInt(n, 10))
+			incr = pipe.Incr("counter")
+			return nil
+		})
+	}, "counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := incr.Result(); err != nil || n != 6 {
+		t.Errorf("unexpected incr result %d %v", n, err)
+	}
+	var sent []string
+	for i := 0; i < 6; i++ {
+		sent = append(sent, (<-commands)[0])
+	}
+	if strings.Join(sent, " ") != "WATCH GET MULTI SET INCR EXEC" {
+		t.Errorf("unexpected commands %v", sent)
+	}
+}
+
+func TestTxExecReplyMismatch(t *testing.T) {
+	addr, _ := serveReplies(t, "+OK\r\n", "+QUEUED\r\n", "+QUEUED\r\n", "*1\r\n+OK\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	var incr *IntCmd
+	err = rr.Watch(context.Background(), func(tx *Tx) error {
+		return tx.Exec(func(pipe *Pipeline) error {
+			pipe.Set("seen", "1")
+			incr = pipe.Incr("counter")
+			return nil
+		})
+	})
+	if err == nil {
+		t.Fatal("expected a reply count mismatch")
+	}
+	if _, cmdErr := incr.Result(); cmdErr == nil || cmdErr.Error() != err.Error() {
+		t.Errorf("expected the command to fail with %v, got %v", err, cmdErr)
+	}
+}
+
+func TestWatchTxFailed(t *testing.T) {
+	aborted := []string{"+OK\r\n", "+OK\r\n", "+QUEUED\r\n", "*-1\r\n"}
+	addr, _ := serveReplies(t, append(aborted, aborted...)...)
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	calls := 0
+	err = rr.WatchWithAttempts(context.Background(), 2, func(tx *Tx) error {
+		calls++
+		return tx.Exec(func(pipe *Pipeline) error {
+			pipe.Incr("counter")
+			return nil
+		})
+	}, "counter")
+	if err != ErrTxFailed {
+		t.Errorf("expected ErrTxFailed, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestWatchUnwatch(t *testing.T) {
+	addr, commands := serveReplies(t, "+OK\r\n", "+OK\r\n")
+	rr, err := DialWithConfig(&DialConfig{Address: addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.ClosePool()
+	abort := errors.New("abort")
+	if err := rr.Watch(context.Background(), func(tx *Tx) error { return abort }, "key"); err != abort {
+		t.Errorf("expected the error of fn, got %v", err)
+	}
+	<-commands
+	if cmd := <-commands; cmd[0] != "UNWATCH" {
+		t.Errorf("expected UNWATCH, got %v", cmd)
+	}
+}
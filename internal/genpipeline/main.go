@@ -0,0 +1,323 @@
+// Command genpipeline generates the command methods of client.Pipeline from
+// the methods of client.Redis. It is run by go generate in the client
+// directory and writes pipeline_cmds.go.
+//
+// A Redis method is wrapped if it returns (T, error) or error and its body
+// calls r.ExecuteCommand exactly once, outside of any loop, without calling
+// other methods of Redis or assigning to its fields.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const output = "pipeline_cmds.go"
+
+// cmdTypes are the Cmd aliases declared in pipeline.go
+var cmdTypes = map[string]string{
+	"*Reply":                 "ReplyCmd",
+	"int64":                  "IntCmd",
+	"bool":                   "BoolCmd",
+	"float64":                "FloatCmd",
+	"string":                 "StringCmd",
+	"[]byte":                 "BytesCmd",
+	"[]string":               "StringSliceCmd",
+	"[][]byte":               "BytesSliceCmd",
+	"[]bool":                 "BoolSliceCmd",
+	"[]int64":                "IntSliceCmd",
+	"[]float64":              "FloatSliceCmd",
+	"map[string]string":      "StringMapCmd",
+	"map[string]interface{}": "InterfaceMapCmd",
+	"[]ZMember":              "ZMemberSliceCmd",
+	"[]StreamEntry":          "StreamEntriesCmd",
+}
+
+// reserved are the methods of Pipeline itself
+var reserved = map[string]bool{
+	"Do": true, "Len": true, "Discard": true, "Exec": true, "Pipeline": true,
+}
+
+type method struct {
+	decl    *ast.FuncDecl
+	file    *ast.File
+	recv    string
+	result  string // "" for methods returning only an error
+	imports map[string]string
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		name := fi.Name()
+		return !strings.HasSuffix(name, "_test.go") && name != output
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkg, ok := pkgs["client"]
+	if !ok {
+		log.Fatal("package client not found")
+	}
+
+	var all []method
+	names := make(map[string]bool)
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+			star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			if id, ok := star.X.(*ast.Ident); !ok || id.Name != "Redis" {
+				continue
+			}
+			names[fn.Name.Name] = true
+			if len(fn.Recv.List[0].Names) == 0 {
+				continue
+			}
+			all = append(all, method{decl: fn, file: file, recv: fn.Recv.List[0].Names[0].Name})
+		}
+	}
+
+	var methods []method
+	for _, m := range all {
+		if m.decl.Name.IsExported() && !reserved[m.decl.Name.Name] && m.decl.Type.TypeParams == nil && wrappable(fset, &m, names) {
+			methods = append(methods, m)
+		}
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].decl.Name.Name < methods[j].decl.Name.Name
+	})
+
+	imports := make(map[string]string)
+	var body bytes.Buffer
+	for _, m := range methods {
+		for name, path := range m.imports {
+			imports[name] = path
+		}
+		if err := writeMethod(&body, fset, m); err != nil {
+			log.Fatalf("%s: %v", m.decl.Name.Name, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by genpipeline from the methods of Redis. DO NOT EDIT.\n\npackage client\n\n")
+	if len(imports) > 0 {
+		var paths []string
+		for _, path := range imports {
+			paths = append(paths, strconv.Quote(path))
+		}
+		sort.Strings(paths)
+		fmt.Fprintf(&buf, "import (\n%s\n)\n\n", strings.Join(paths, "\n"))
+	}
+	buf.Write(body.Bytes())
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// wrappable reports whether m can be queued on a pipeline, setting its
+// result type and the imports its signature needs
+func wrappable(fset *token.FileSet, m *method, names map[string]bool) bool {
+	results := fieldTypes(m.decl.Type.Results)
+	switch {
+	case len(results) == 1 && isError(results[0]):
+	case len(results) == 2 && isError(results[1]):
+		m.result = typeString(fset, results[0])
+	default:
+		return false
+	}
+	if m.decl.Body == nil {
+		return false
+	}
+
+	calls, ok := 0, true
+	var loops []ast.Node
+	ast.Inspect(m.decl.Body, func(n ast.Node) bool {
+		if !ok {
+			return false
+		}
+		if n == nil {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			loops = append(loops, n)
+		case *ast.GoStmt:
+			ok = false
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if sel, isSel := lhs.(*ast.SelectorExpr); isSel && isIdent(sel.X, m.recv) {
+					ok = false
+				}
+			}
+		case *ast.CallExpr:
+			sel, isSel := n.Fun.(*ast.SelectorExpr)
+			if !isSel {
+				break
+			}
+			switch {
+			case isIdent(sel.X, m.recv) && sel.Sel.Name == "ExecuteCommand":
+				calls++
+				for _, loop := range loops {
+					if loop.Pos() <= n.Pos() && n.End() <= loop.End() {
+						ok = false
+					}
+				}
+			case isIdent(sel.X, m.recv) && names[sel.Sel.Name]:
+				ok = false
+			case isIdent(sel.X, "fmt") && strings.HasPrefix(sel.Sel.Name, "Print"), isIdent(sel.X, "log"):
+				ok = false
+			}
+		}
+		return true
+	})
+	if !ok || calls != 1 {
+		return false
+	}
+
+	m.imports = make(map[string]string)
+	ast.Inspect(m.decl.Type, func(n ast.Node) bool {
+		if sel, isSel := n.(*ast.SelectorExpr); isSel {
+			if id, isId := sel.X.(*ast.Ident); isId {
+				if path := importPath(m.file, id.Name); path != "" {
+					m.imports[id.Name] = path
+				}
+			}
+		}
+		return true
+	})
+	return true
+}
+
+// writeMethod writes the Pipeline method queuing m
+func writeMethod(w *bytes.Buffer, fset *token.FileSet, m method) error {
+	name := m.decl.Name.Name
+	var params, args []string
+	used := make(map[string]bool)
+	if m.decl.Type.Params != nil {
+		n := 0
+		for _, field := range m.decl.Type.Params.List {
+			typ := typeString(fset, field.Type)
+			_, variadic := field.Type.(*ast.Ellipsis)
+			idents := field.Names
+			if len(idents) == 0 {
+				idents = []*ast.Ident{{Name: "_"}}
+			}
+			for _, id := range idents {
+				arg := id.Name
+				if arg == "_" {
+					arg = fmt.Sprintf("arg%d", n)
+				}
+				n++
+				used[arg] = true
+				params = append(params, arg+" "+typ)
+				if variadic {
+					arg += "..."
+				}
+				args = append(args, arg)
+			}
+		}
+	}
+	recv, err := pickName(used, "p", "pipe")
+	if err != nil {
+		return err
+	}
+	used[recv] = true
+	rd, err := pickName(used, "r", "rd")
+	if err != nil {
+		return err
+	}
+
+	ret, result := "*StatusCmd", "struct{}"
+	call := fmt.Sprintf("%s.%s(%s)", rd, name, strings.Join(args, ", "))
+	if m.result != "" {
+		result = m.result
+		ret = "*Cmd[" + result + "]"
+		if alias, ok := cmdTypes[result]; ok {
+			ret = "*" + alias
+		}
+	} else {
+		call = "struct{}{}, " + call
+	}
+	fmt.Fprintf(w, "// %s queues Redis.%s on the pipeline.\n", name, name)
+	fmt.Fprintf(w, "func (%s *Pipeline) %s(%s) %s {\n", recv, name, strings.Join(params, ", "), ret)
+	fmt.Fprintf(w, "\treturn queue(%s, func(%s *Redis) (%s, error) { return %s })\n}\n\n", recv, rd, result, call)
+	return nil
+}
+
+func pickName(used map[string]bool, names ...string) (string, error) {
+	for _, name := range names {
+		if !used[name] {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("parameter names clash with %v", names)
+}
+
+func fieldTypes(fields *ast.FieldList) []ast.Expr {
+	if fields == nil {
+		return nil
+	}
+	var types []ast.Expr
+	for _, field := range fields.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			types = append(types, field.Type)
+		}
+	}
+	return types
+}
+
+func isError(expr ast.Expr) bool {
+	return isIdent(expr, "error")
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+func typeString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
+
+// importPath returns the path of the package imported as name by file
+func importPath(file *ast.File, name string) string {
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil {
+			if spec.Name.Name == name {
+				return path
+			}
+			continue
+		}
+		if path == name || strings.HasSuffix(path, "/"+name) {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,14 @@
+// Package lock provides distributed locks on top of client.Redis.
+//
+// A lock is a key set with SET NX PX to a random token, so that only its
+// owner releases or extends it, through scripts comparing the token. With
+// several independent servers the Redlock algorithm is used: the lock is
+// held when a majority of them granted it within its lease time.
+//
+//	locker := lock.New(redis)
+//	l, err := locker.Obtain(ctx, "resource", 10*time.Second)
+//	if err != nil {
+//		return err
+//	}
+//	defer l.Release(context.Background())
+package lock
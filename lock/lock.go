@@ -0,0 +1,355 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	mrand "math/rand"
+	"sync"
+	"time"
+
+	"github.com/therealbill/libredis/client"
+)
+
+// Defaults of Config
+const (
+	DefaultMinRetryDelay = 10 * time.Millisecond
+	DefaultMaxRetryDelay = 500 * time.Millisecond
+	DefaultDriftFactor   = 0.01
+)
+
+var (
+	// ErrNotObtained is returned when the lock is held by someone else
+	ErrNotObtained = errors.New("lock: not obtained")
+	// ErrNotHeld is returned when releasing or extending a lock which
+	// expired or was taken over
+	ErrNotHeld = errors.New("lock: not held")
+)
+
+// releaseScript deletes the key if it still holds the token
+var releaseScript = client.NewScript(`if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0`, 1)
+
+// extendScript resets the expiry of the key if it still holds the token
+var extendScript = client.NewScript(`if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0`, 1)
+
+// Config controls how a Locker obtains and keeps locks
+type Config struct {
+	// MinRetryDelay and MaxRetryDelay bound the backoff between the attempts
+	// of Obtain, the delay doubles from MinRetryDelay after each attempt and
+	// is jittered
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+	// DriftFactor is the share of the lease time deducted from the validity
+	// of a lock to account for clock drift between the servers
+	DriftFactor float64
+	// AutoExtend extends the lease of obtained locks in the background every
+	// third of the lease time until they are released, see Lock.Lost
+	AutoExtend bool
+}
+
+// Locker obtains locks from one server, or from several independent servers
+// following the Redlock algorithm
+type Locker struct {
+	clients []*client.Redis
+	quorum  int
+	cfg     Config
+}
+
+// New returns a Locker with the default Config. With several clients, which
+// must be independent masters rather than replicas or nodes of a cluster, a
+// lock is held once a majority of them granted it.
+func New(clients ...*client.Redis) *Locker {
+	return NewWithConfig(nil, clients...)
+}
+
+// NewWithConfig returns a Locker using cfg, see New
+func NewWithConfig(cfg *Config, clients ...*client.Redis) *Locker {
+	if len(clients) == 0 {
+		panic("lock: no clients")
+	}
+	l := &Locker{clients: clients, quorum: len(clients)/2 + 1}
+	if cfg != nil {
+		l.cfg = *cfg
+	}
+	if l.cfg.MinRetryDelay <= 0 {
+		l.cfg.MinRetryDelay = DefaultMinRetryDelay
+	}
+	if l.cfg.MaxRetryDelay < l.cfg.MinRetryDelay {
+		l.cfg.MaxRetryDelay = DefaultMaxRetryDelay
+		if l.cfg.MaxRetryDelay < l.cfg.MinRetryDelay {
+			l.cfg.MaxRetryDelay = l.cfg.MinRetryDelay
+		}
+	}
+	if l.cfg.DriftFactor <= 0 {
+		l.cfg.DriftFactor = DefaultDriftFactor
+	}
+	return l
+}
+
+// Obtain obtains the lock on key for ttl, waiting while it is held by someone
+// else until ctx is done, in which case the error of ctx is returned
+func (l *Locker) Obtain(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	delay := l.cfg.MinRetryDelay
+	for {
+		lk, err := l.TryObtain(ctx, key, ttl)
+		if err != ErrNotObtained {
+			return lk, err
+		}
+		// jitter spreads out the retries of competing clients
+		wait := delay/2 + time.Duration(mrand.Int63n(int64(delay/2)+1))
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+		if delay *= 2; delay > l.cfg.MaxRetryDelay {
+			delay = l.cfg.MaxRetryDelay
+		}
+	}
+}
+
+// TryObtain makes a single attempt to obtain the lock on key for ttl,
+// returning ErrNotObtained if it is held by someone else
+func (l *Locker) TryObtain(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if ttl < time.Millisecond {
+		return nil, errors.New("lock: ttl must be at least a millisecond")
+	}
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	// waiting past the lease time would only obtain an expired lock
+	nodeCtx, cancel := context.WithTimeout(ctx, ttl)
+	n, err := l.each(nodeCtx, func(r *client.Redis) (bool, error) {
+		rp, err := r.ExecuteCommand("SET", key, token, "NX", "PX", int64(ttl/time.Millisecond))
+		if err != nil {
+			return false, err
+		}
+		return !rp.IsNull(), nil
+	})
+	cancel()
+	until := start.Add(ttl - l.drift(ttl))
+	if n >= l.quorum && time.Now().Before(until) {
+		lk := &Lock{locker: l, key: key, token: token, ttl: ttl, until: until, lost: make(chan struct{})}
+		if l.cfg.AutoExtend {
+			lk.stop = make(chan struct{})
+			lk.done = make(chan struct{})
+			go lk.autoExtend()
+		}
+		return lk, nil
+	}
+	// release the minority of servers which granted the lock
+	if n > 0 {
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ttl)
+		l.release(releaseCtx, key, token)
+		cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if n < l.quorum && err != nil && n+l.failures(err) >= l.quorum {
+		// the quorum was lost to errors rather than to another owner
+		return nil, err
+	}
+	return nil, ErrNotObtained
+}
+
+// drift is the part of the lease time lost to clock drift
+func (l *Locker) drift(ttl time.Duration) time.Duration {
+	return time.Duration(float64(ttl)*l.cfg.DriftFactor) + 2*time.Millisecond
+}
+
+// each runs fn on every client concurrently, returning how many of them
+// returned true and the errors joined
+func (l *Locker) each(ctx context.Context, fn func(r *client.Redis) (bool, error)) (int, error) {
+	type result struct {
+		ok  bool
+		err error
+	}
+	results := make(chan result, len(l.clients))
+	for _, r := range l.clients {
+		go func(r *client.Redis) {
+			ok, err := fn(r.WithContext(ctx))
+			results <- result{ok, err}
+		}(r)
+	}
+	var n int
+	var errs []error
+	for range l.clients {
+		res := <-results
+		if res.ok {
+			n++
+		}
+		if res.err != nil {
+			errs = append(errs, res.err)
+		}
+	}
+	return n, errors.Join(errs...)
+}
+
+// failures counts the errors joined by each
+func (l *Locker) failures(err error) int {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return len(joined.Unwrap())
+	}
+	return 1
+}
+
+func (l *Locker) release(ctx context.Context, key, token string) (int, error) {
+	return l.each(ctx, func(r *client.Redis) (bool, error) {
+		rp, err := releaseScript.Run(r, key, token)
+		if err != nil {
+			return false, err
+		}
+		n, err := rp.IntegerValue()
+		return n == 1, err
+	})
+}
+
+func (l *Locker) extend(ctx context.Context, key, token string, ttl time.Duration) (int, error) {
+	return l.each(ctx, func(r *client.Redis) (bool, error) {
+		rp, err := extendScript.Run(r, key, token, int64(ttl/time.Millisecond))
+		if err != nil {
+			return false, err
+		}
+		n, err := rp.IntegerValue()
+		return n == 1, err
+	})
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Lock is an obtained lock
+type Lock struct {
+	locker *Locker
+	key    string
+	token  string
+
+	mu    sync.Mutex
+	ttl   time.Duration
+	until time.Time
+
+	lost     chan struct{}
+	lostOnce sync.Once
+	stop     chan struct{} // nil without AutoExtend
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Key returns the locked key
+func (lk *Lock) Key() string {
+	return lk.key
+}
+
+// Token returns the random value identifying the owner of the lock
+func (lk *Lock) Token() string {
+	return lk.token
+}
+
+// TTL returns how long the lock is still known to be held, 0 once expired
+func (lk *Lock) TTL() time.Duration {
+	lk.mu.Lock()
+	defer lk.mu.Unlock()
+	if d := time.Until(lk.until); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Lost returns a channel closed when the lease of an auto-extended lock
+// could not be extended before it expired, after which the work protected by
+// the lock should stop. It is never closed without Config.AutoExtend.
+func (lk *Lock) Lost() <-chan struct{} {
+	return lk.lost
+}
+
+// Extend resets the lease of the lock to ttl, returning ErrNotHeld if it
+// expired or was taken over
+func (lk *Lock) Extend(ctx context.Context, ttl time.Duration) error {
+	if ttl < time.Millisecond {
+		return errors.New("lock: ttl must be at least a millisecond")
+	}
+	l := lk.locker
+	start := time.Now()
+	n, err := l.extend(ctx, lk.key, lk.token, ttl)
+	until := start.Add(ttl - l.drift(ttl))
+	if n < l.quorum || !time.Now().Before(until) {
+		if err != nil && n+l.failures(err) >= l.quorum {
+			return err
+		}
+		return ErrNotHeld
+	}
+	lk.mu.Lock()
+	lk.ttl = ttl
+	lk.until = until
+	lk.mu.Unlock()
+	return nil
+}
+
+// Release stops extending the lock and deletes it, returning ErrNotHeld if
+// it had already expired or was taken over
+func (lk *Lock) Release(ctx context.Context) error {
+	lk.stopExtending()
+	l := lk.locker
+	n, err := l.release(ctx, lk.key, lk.token)
+	lk.mu.Lock()
+	lk.until = time.Time{}
+	lk.mu.Unlock()
+	if n < l.quorum {
+		if err != nil && n+l.failures(err) >= l.quorum {
+			return err
+		}
+		return ErrNotHeld
+	}
+	return nil
+}
+
+func (lk *Lock) stopExtending() {
+	if lk.stop == nil {
+		return
+	}
+	lk.stopOnce.Do(func() { close(lk.stop) })
+	<-lk.done
+}
+
+// autoExtend extends the lease every third of its length until the lock is
+// released, or lost as it could not be extended in time
+func (lk *Lock) autoExtend() {
+	defer close(lk.done)
+	for {
+		lk.mu.Lock()
+		ttl, until := lk.ttl, lk.until
+		lk.mu.Unlock()
+		t := time.NewTimer(ttl / 3)
+		select {
+		case <-lk.stop:
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		ctx, cancel := context.WithDeadline(context.Background(), until)
+		err := lk.Extend(ctx, ttl)
+		cancel()
+		// transient errors are retried at the next tick while the lease lasts
+		if err == ErrNotHeld || err != nil && lk.TTL() == 0 {
+			lk.lostOnce.Do(func() { close(lk.lost) })
+			return
+		}
+	}
+}
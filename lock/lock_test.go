@@ -0,0 +1,231 @@
+package lock
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/therealbill/libredis/client"
+)
+
+// fakeServer keeps string keys and runs the lock scripts, which it tells
+// apart by their source
+type fakeServer struct {
+	mu      sync.Mutex
+	keys    map[string]string
+	scripts map[string]string
+}
+
+func serveFake(t *testing.T) (*fakeServer, *client.Redis) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	s := &fakeServer{keys: make(map[string]string), scripts: make(map[string]string)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	r, err := client.DialWithConfig(&client.DialConfig{Address: ln.Addr().String(), Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(r.ClosePool)
+	return s, r
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	br := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(br)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, s.reply(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeServer) reply(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch strings.ToUpper(args[0]) {
+	case "SET":
+		if _, ok := s.keys[args[1]]; ok {
+			return "$-1\r\n"
+		}
+		s.keys[args[1]] = args[2]
+		return "+OK\r\n"
+	case "SCRIPT":
+		sum := sha1.Sum([]byte(args[2]))
+		hash := hex.EncodeToString(sum[:])
+		s.scripts[hash] = args[2]
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(hash), hash)
+	case "EVALSHA":
+		src, ok := s.scripts[args[1]]
+		if !ok {
+			return "-NOSCRIPT No matching script.\r\n"
+		}
+		key, token := args[3], args[4]
+		if s.keys[key] != token {
+			return ":0\r\n"
+		}
+		if !strings.Contains(src, "pexpire") {
+			delete(s.keys, key)
+		}
+		return ":1\r\n"
+	}
+	return "+OK\r\n"
+}
+
+func readCommand(br *bufio.Reader) ([]string, error) {
+	line, err := br.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if line, err = br.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func (s *fakeServer) get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.keys[key]
+	return v, ok
+}
+
+func (s *fakeServer) set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = value
+}
+
+func TestObtainRelease(t *testing.T) {
+	s, r := serveFake(t)
+	ctx := context.Background()
+	locker := New(r)
+	lk, err := locker.Obtain(ctx, "resource", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := s.get("resource"); v != lk.Token() {
+		t.Errorf("expected the key to hold the token %q, got %q", lk.Token(), v)
+	}
+	if ttl := lk.TTL(); ttl <= 0 || ttl > time.Second {
+		t.Errorf("unexpected ttl %v", ttl)
+	}
+	if _, err := locker.TryObtain(ctx, "resource", time.Second); err != ErrNotObtained {
+		t.Errorf("expected ErrNotObtained, got %v", err)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := locker.Obtain(waitCtx, "resource", time.Second); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if err := lk.Extend(ctx, 2*time.Second); err != nil {
+		t.Errorf("Extend: %v", err)
+	}
+	if err := lk.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.get("resource"); ok {
+		t.Error("expected the key to be deleted")
+	}
+	if err := lk.Release(ctx); err != ErrNotHeld {
+		t.Errorf("expected ErrNotHeld, got %v", err)
+	}
+	if err := lk.Extend(ctx, time.Second); err != ErrNotHeld {
+		t.Errorf("expected ErrNotHeld, got %v", err)
+	}
+}
+
+func TestRedlockQuorum(t *testing.T) {
+	var servers []*fakeServer
+	var clients []*client.Redis
+	for i := 0; i < 3; i++ {
+		s, r := serveFake(t)
+		servers = append(servers, s)
+		clients = append(clients, r)
+	}
+	ctx := context.Background()
+	locker := New(clients...)
+
+	// one server held by someone else still leaves a majority
+	servers[0].set("a", "other")
+	lk, err := locker.TryObtain(ctx, "a", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lk.Release(ctx); err != nil {
+		t.Errorf("Release: %v", err)
+	}
+
+	// the minority granting the lock must be released
+	servers[0].set("b", "other")
+	servers[1].set("b", "other")
+	if _, err := locker.TryObtain(ctx, "b", time.Second); err != ErrNotObtained {
+		t.Fatalf("expected ErrNotObtained, got %v", err)
+	}
+	if _, ok := servers[2].get("b"); ok {
+		t.Error("expected the lock granted by a minority to be released")
+	}
+}
+
+func TestAutoExtend(t *testing.T) {
+	s, r := serveFake(t)
+	ctx := context.Background()
+	locker := NewWithConfig(&Config{AutoExtend: true}, r)
+	lk, err := locker.Obtain(ctx, "resource", 60*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if lk.TTL() == 0 {
+		t.Error("expected the lease to be extended")
+	}
+	// taking the key over makes the next extension fail
+	s.set("resource", "other")
+	select {
+	case <-lk.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("expected the lock to be lost")
+	}
+	if err := lk.Release(ctx); err != ErrNotHeld {
+		t.Errorf("expected ErrNotHeld, got %v", err)
+	}
+}
@@ -20,3 +20,29 @@ type ClusterSlot struct {
 	MasterPort int64
 	Slaves     []string
 }
+
+// ClusterSlotRange is a contiguous range of hash slots served by a shard
+type ClusterSlotRange struct {
+	Start int64
+	End   int64
+}
+
+// ClusterShardNode is a node entry as returned by CLUSTER SHARDS
+type ClusterShardNode struct {
+	Id                string
+	Port              int64
+	TLSPort           int64
+	Ip                string
+	Endpoint          string
+	Hostname          string
+	Role              string
+	ReplicationOffset int64
+	Health            string
+}
+
+// ClusterShard is a shard as returned by CLUSTER SHARDS, the slot ranges it
+// serves and the master and replica nodes holding them
+type ClusterShard struct {
+	Slots []ClusterSlotRange
+	Nodes []ClusterShardNode
+}